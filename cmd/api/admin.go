@@ -0,0 +1,68 @@
+package main
+
+import "net/http"
+
+type LogLevel struct {
+	Level string `json:"level"`
+}
+
+type UpdateLogLevelPayload struct {
+	Level string `json:"level" validate:"required,oneof=debug info warn error"`
+}
+
+func (p *UpdateLogLevelPayload) normalize() {
+	trimString(&p.Level)
+}
+
+// GetLogLevel godoc
+//
+//	@Summary		Get the log level
+//	@Description	Get the minimum level the service currently logs
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	LogLevel
+//	@Failure		401	{object}	Problem
+//	@Failure		403	{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/admin/log-level [get]
+func (app *application) getLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	if err := app.jsonResponse(w, http.StatusOK, LogLevel{Level: app.logger.Level()}); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// UpdateLogLevel godoc
+//
+//	@Summary		Update the log level
+//	@Description	Change the minimum level the service logs until it restarts
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		UpdateLogLevelPayload	true	"Log level payload"
+//	@Success		200		{object}	LogLevel
+//	@Failure		400		{object}	Problem
+//	@Failure		401		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/admin/log-level [put]
+func (app *application) updateLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	payload, err := decodeJSON[UpdateLogLevelPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	previous := app.logger.Level()
+	if err := app.logger.SetLevel(payload.Level); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	// logged at warn so the change is recorded whatever the new level
+	app.requestLogger(r).Warnw("log level changed", "from", previous, "to", payload.Level, "principal", getPrincipalFromContext(r).ID)
+
+	if err := app.jsonResponse(w, http.StatusOK, LogLevel{Level: app.logger.Level()}); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
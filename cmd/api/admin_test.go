@@ -0,0 +1,64 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/logger"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+	"go.uber.org/zap"
+)
+
+func TestLogLevel(t *testing.T) {
+	app := newTestApplication(t, store.Storage{Users: new(mocks.MockUserStore)})
+	testLogger, logs := logger.NewTestLogger()
+	app.logger = testLogger
+	mux := app.mount()
+
+	newRequest := func(method, payload string) *http.Request {
+		req := newJSONRequest(method, newCollectionPath("admin/log-level"), strings.NewReader(payload))
+		return req
+	}
+
+	t.Run("should return 401 - anonymous", func(t *testing.T) {
+		res := execRequest(mux, newRequest(http.MethodGet, ""))
+
+		assertStatusCode(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("should return 403 - member", func(t *testing.T) {
+		res := execRequest(mux, authorize(t, app, newRequest(http.MethodPut, `{"level": "debug"}`), mocks.MockMemberID))
+
+		assertStatusCode(t, res.Code, http.StatusForbidden)
+	})
+
+	t.Run("should return 400 - unknown level", func(t *testing.T) {
+		res := execRequest(mux, authorize(t, app, newRequest(http.MethodPut, `{"level": "verbose"}`), mocks.MockAdminID))
+
+		assertStatusCode(t, res.Code, http.StatusBadRequest)
+	})
+
+	t.Run("should return 200 - level changed", func(t *testing.T) {
+		res := execRequest(mux, authorize(t, app, newRequest(http.MethodPut, `{"level": "warn"}`), mocks.MockAdminID))
+
+		assertStatusCode(t, res.Code, http.StatusOK)
+
+		if level := app.logger.Level(); level != "warn" {
+			t.Errorf("got level %q want %q", level, "warn")
+		}
+
+		if n := logs.FilterMessage("log level changed").FilterField(zap.String("to", "warn")).Len(); n != 1 {
+			t.Errorf("got %d level change entries want 1", n)
+		}
+
+		res = execRequest(mux, authorize(t, app, newRequest(http.MethodGet, ""), mocks.MockAdminID))
+
+		assertStatusCode(t, res.Code, http.StatusOK)
+
+		if !strings.Contains(res.Body.String(), `"level":"warn"`) {
+			t.Errorf("expected the new level in %q", res.Body.String())
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/JerryLegend254/mfit_api/internal/analysis"
+	"github.com/JerryLegend254/mfit_api/internal/units"
+)
+
+type MuscleLoadItemPayload struct {
+	WorkoutID int64 `json:"workout_id" validate:"required,gt=0"`
+	Sets      int   `json:"sets" validate:"required,gte=1,lte=50"`
+}
+
+// MuscleLoadPayload lists planned workouts, secondary_weight overrides how
+// much a set counts toward its secondary targets.
+type MuscleLoadPayload struct {
+	Items           []MuscleLoadItemPayload `json:"items" validate:"required,min=1,max=100,dive"`
+	SecondaryWeight *float64                `json:"secondary_weight" validate:"omitnil,gte=0,lte=1"`
+}
+
+type MuscleLoadResponse struct {
+	SecondaryWeight float64 `json:"secondary_weight"`
+	analysis.MuscleLoad
+}
+
+// AnalyzeMuscleLoad godoc
+//
+//	@Summary		Analyzes muscle load
+//	@Description	Attributes planned sets to the targets and body parts of each workout, with secondary targets weighted at a fraction, and flags imbalances such as push against pull
+//	@Tags			analysis
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		MuscleLoadPayload	true	"Planned workouts"
+//	@Success		200		{object}	MuscleLoadResponse
+//	@Failure		400		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/analysis/muscle-load [post]
+func (app *application) analyzeMuscleLoadHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	payload, err := decodeJSON[MuscleLoadPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	secondaryWeight := app.config.analysis.secondaryWeight
+	if payload.SecondaryWeight != nil {
+		secondaryWeight = *payload.SecondaryWeight
+	}
+
+	ids := make([]int64, len(payload.Items))
+	for i, item := range payload.Items {
+		ids[i] = item.WorkoutID
+	}
+
+	workouts, err := app.store.Workouts.GetByIDs(ctx, ids)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	known := make(map[int64]bool, len(workouts))
+	for _, workout := range workouts {
+		known[workout.ID] = true
+	}
+
+	for i, item := range payload.Items {
+		if !known[item.WorkoutID] {
+			app.badRequest(w, r, newFieldError(fmt.Sprintf("items[%d].workout_id", i), "exists", "is not a known workout"))
+			return
+		}
+	}
+
+	targets, err := app.store.Workouts.GetTargets(ctx, ids)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	items := make([]analysis.Item, len(payload.Items))
+	for i, item := range payload.Items {
+		items[i] = analysis.Item{WorkoutID: item.WorkoutID, Sets: item.Sets}
+		for _, t := range targets[item.WorkoutID] {
+			items[i].Targets = append(items[i].Targets, analysis.Target{
+				ID:         t.ID,
+				Name:       t.Name,
+				Type:       t.Type,
+				BodyPartID: t.BodyPartID,
+				BodyPart:   t.BodyPart,
+			})
+		}
+	}
+
+	res := MuscleLoadResponse{
+		SecondaryWeight: secondaryWeight,
+		MuscleLoad:      analysis.Analyze(items, secondaryWeight, analysis.DefaultBalances),
+	}
+	for i := range res.Balances {
+		if ratio := res.Balances[i].Ratio; ratio != nil {
+			*ratio = units.Round(*ratio, 2)
+		}
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, res); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+var MuscleLoadUrl = newCollectionPath("analysis/muscle-load")
+
+func TestAnalyzeMuscleLoad(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		Workouts: new(mocks.MockWorkoutStore),
+	})
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		payload            string
+		expectedStatusCode int
+		expectedRatio      *float64
+		expectedFlagged    bool
+		expectedChest      float64
+	}{
+		// push: 4 pectorals + 4 × 0.5 triceps, pull: 4 lats + 4 × 0.5 biceps
+		{"should return 200 - balanced", `{"items": [{"workout_id": 1, "sets": 4}, {"workout_id": 2, "sets": 4}]}`, http.StatusOK, ptr(1.0), false, 4},
+		{"should return 200 - push heavy", `{"items": [{"workout_id": 1, "sets": 6}, {"workout_id": 3, "sets": 4}, {"workout_id": 2, "sets": 3}]}`, http.StatusOK, ptr(3.33), true, 10},
+		// push: 4 + 4 × 0, pull: 2 + 2 × 0
+		{"should return 200 - secondary weight override", `{"items": [{"workout_id": 1, "sets": 4}, {"workout_id": 2, "sets": 2}], "secondary_weight": 0}`, http.StatusOK, ptr(2.0), true, 4},
+		{"should return 200 - push only", `{"items": [{"workout_id": 1, "sets": 4}]}`, http.StatusOK, nil, true, 4},
+		{"should return 400 - unknown workout", fmt.Sprintf(`{"items": [{"workout_id": 1, "sets": 4}, {"workout_id": %d, "sets": 3}]}`, mocks.MockMissingWorkoutID), http.StatusBadRequest, nil, false, 0},
+		{"should return 400 - no items", `{"items": []}`, http.StatusBadRequest, nil, false, 0},
+		{"should return 400 - zero sets", `{"items": [{"workout_id": 1, "sets": 0}]}`, http.StatusBadRequest, nil, false, 0},
+		{"should return 400 - weight above one", `{"items": [{"workout_id": 1, "sets": 4}], "secondary_weight": 1.5}`, http.StatusBadRequest, nil, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(http.MethodPost, MuscleLoadUrl, bytes.NewBufferString(tt.payload))
+
+			res := execRequest(mux, req)
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			if tt.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Data MuscleLoadResponse `json:"data"`
+			}
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			pushPull := body.Data.Balances[0]
+			if (pushPull.Ratio == nil) != (tt.expectedRatio == nil) || (pushPull.Ratio != nil && *pushPull.Ratio != *tt.expectedRatio) {
+				t.Errorf("got push/pull ratio %v want %v", pushPull.Ratio, tt.expectedRatio)
+			}
+
+			if pushPull.Flagged != tt.expectedFlagged {
+				t.Errorf("got flagged %v want %v", pushPull.Flagged, tt.expectedFlagged)
+			}
+
+			if chest := body.Data.BodyParts[0]; chest.BodyPart != "chest" || chest.Volume != tt.expectedChest {
+				t.Errorf("got top body part %+v want chest at %v", chest, tt.expectedChest)
+			}
+		})
+	}
+}
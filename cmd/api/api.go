@@ -6,23 +6,87 @@ import (
 	"time"
 
 	"github.com/JerryLegend254/mfit_api/docs"
+	"github.com/JerryLegend254/mfit_api/internal/auth"
 	"github.com/JerryLegend254/mfit_api/internal/logger"
+	"github.com/JerryLegend254/mfit_api/internal/mailer"
+	"github.com/JerryLegend254/mfit_api/internal/metrics"
 	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/units"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
 type application struct {
-	config config
-	store  store.Storage
-	logger logger.Logger
+	config        config
+	store         store.Storage
+	logger        logger.Logger
+	authenticator auth.Authenticator
+	mailer        mailer.Client
 }
 
 type config struct {
-	addr   string
-	db     dbConfig
-	apiURL string
+	addr        string
+	db          dbConfig
+	apiURL      string
+	frontendURL string
+	auth        authConfig
+	mail        mailConfig
+	strength    strengthConfig
+	analysis    analysisConfig
+}
+
+// analysisConfig sets the default fraction of a set that counts toward the
+// secondary targets of a workout.
+type analysisConfig struct {
+	secondaryWeight float64
+}
+
+// strengthConfig sets the default rounding of strength load tables, usually
+// the smallest plate pair available in each unit.
+type strengthConfig struct {
+	incrementKg float64
+	incrementLb float64
+}
+
+func (c strengthConfig) increment(unit string) float64 {
+	if unit == units.Pound {
+		return c.incrementLb
+	}
+	return c.incrementKg
+}
+
+type mailConfig struct {
+	fromEmail string
+	// lifetimes of the activation and password reset links
+	exp      time.Duration
+	resetExp time.Duration
+	// dir receives messages when no SMTP host is configured
+	dir  string
+	smtp smtpConfig
+}
+
+type smtpConfig struct {
+	host     string
+	port     int
+	username string
+	password string
+}
+
+type authConfig struct {
+	token        tokenConfig
+	totp         totpConfig
+	staticTokens []auth.StaticToken
+}
+
+type totpConfig struct {
+	issuer string
+}
+
+type tokenConfig struct {
+	secret string
+	iss    string
+	exp    time.Duration
 }
 
 type dbConfig struct {
@@ -34,7 +98,9 @@ type dbConfig struct {
 
 func (app *application) mount() http.Handler {
 	r := chi.NewRouter()
-	r.Use(middleware.Logger)
+	r.Use(app.requestIDMiddleware)
+	r.Use(app.metricsMiddleware)
+	r.Use(app.accessLogMiddleware)
 	r.Use(middleware.Recoverer)
 
 	// Swagger Docs
@@ -43,56 +109,189 @@ func (app *application) mount() http.Handler {
 
 	// Handlers
 	r.Route("/api/v1", func(r chi.Router) {
+		r.Use(app.APIKeyMiddleware)
+
 		r.Get("/ping", app.pingHandler)
 
+		// Prometheus scrape endpoint, for admins and metrics:read API keys
+		r.With(app.AuthTokenMiddleware, app.requirePermission(permMetricsRead)).Method(http.MethodGet, "/metrics", metrics.Handler())
+
+		// authentication endpoints
+		r.Route("/auth", func(r chi.Router) {
+			r.Post("/register", app.registerUserHandler)
+			r.Post("/login", app.loginUserHandler)
+			r.Post("/activate", app.activateUserHandler)
+			r.Post("/password/forgot", app.forgotPasswordHandler)
+			r.Post("/password/reset", app.resetPasswordHandler)
+
+			r.Route("/totp", func(r chi.Router) {
+				r.Use(app.AuthTokenMiddleware)
+				r.Post("/enroll", app.enrollTOTPHandler)
+				r.Post("/confirm", app.confirmTOTPHandler)
+				r.With(app.requireStepUp).Delete("/", app.deleteTOTPHandler)
+			})
+		})
+
+		// catalog reads are public, mutations require catalog:write and
+		// deletes additionally a TOTP code
+		catalogRead := app.requirePermissionIfAuthenticated(permCatalogRead)
+		catalogWrite := chi.Chain(app.AuthTokenMiddleware, app.requirePermission(permCatalogWrite))
+
+		r.With(catalogRead).Get("/search", app.searchHandler)
+		r.With(catalogRead).Get("/autocomplete", app.autocompleteHandler)
+		r.With(catalogRead).Post("/analysis/muscle-load", app.analyzeMuscleLoadHandler)
+		r.With(catalogRead).Post("/generate/session", app.generateSessionHandler)
+
 		// body parts endpoints
 		r.Route("/bodyparts", func(r chi.Router) {
-			r.Post("/", app.createBodyPartHandler)
-			r.Get("/", app.fetchBodyPartsHandler)
+			r.With(catalogRead).Get("/", app.fetchBodyPartsHandler)
+			r.With(catalogWrite...).Post("/", app.createBodyPartHandler)
 
 			r.Route("/{bodyPartId}", func(r chi.Router) {
-				r.Use(app.bodyPartContextMiddleware)
-				r.Get("/", app.getBodyPartHandler)
-				r.Patch("/", app.updateBodyPartHandler)
-				r.Delete("/", app.deleteBodyPartHandler)
+				r.With(catalogRead, app.bodyPartContextMiddleware).Get("/", app.getBodyPartHandler)
+
+				r.Group(func(r chi.Router) {
+					r.Use(catalogWrite...)
+					r.Use(app.bodyPartContextMiddleware)
+					r.Patch("/", app.updateBodyPartHandler)
+					r.With(app.requireStepUp).Delete("/", app.deleteBodyPartHandler)
+				})
 			})
 		})
 
 		// targets endpoints
 		r.Route("/targets", func(r chi.Router) {
-			r.Post("/", app.createTargetHandler)
-			r.Get("/", app.fetchTargetsHandler)
+			r.With(catalogRead).Get("/", app.fetchTargetsHandler)
+			r.With(catalogWrite...).Post("/", app.createTargetHandler)
 
 			r.Route("/{targetId}", func(r chi.Router) {
-				r.Use(app.targetContextMiddleware)
-				r.Get("/", app.getTargetHandler)
-				r.Patch("/", app.updateTargetHandler)
-				r.Delete("/", app.deleteTargetHandler)
+				r.With(catalogRead, app.targetContextMiddleware).Get("/", app.getTargetHandler)
+
+				r.Group(func(r chi.Router) {
+					r.Use(catalogWrite...)
+					r.Use(app.targetContextMiddleware)
+					r.Patch("/", app.updateTargetHandler)
+					r.With(app.requireStepUp).Delete("/", app.deleteTargetHandler)
+				})
 			})
 		})
 
 		// equipment endpoints
 		r.Route("/equipment", func(r chi.Router) {
-			r.Post("/", app.createEquipmentHandler)
-			r.Get("/", app.fetchEquipmentsHandler)
+			r.With(catalogRead).Get("/", app.fetchEquipmentsHandler)
+			r.With(catalogWrite...).Post("/", app.createEquipmentHandler)
 
 			r.Route("/{equipmentId}", func(r chi.Router) {
-				r.Use(app.equipmentContextMiddleware)
-				r.Get("/", app.getEquipmentHandler)
-				r.Patch("/", app.updateEquipmentHandler)
-				r.Delete("/", app.deleteEquipmentHandler)
+				r.With(catalogRead, app.equipmentContextMiddleware).Get("/", app.getEquipmentHandler)
+
+				r.Group(func(r chi.Router) {
+					r.Use(catalogWrite...)
+					r.Use(app.equipmentContextMiddleware)
+					r.Patch("/", app.updateEquipmentHandler)
+					r.With(app.requireStepUp).Delete("/", app.deleteEquipmentHandler)
+				})
 			})
 		})
 
 		// workouts endpoints
 		r.Route("/workouts", func(r chi.Router) {
-			r.Post("/", app.createWorkoutHandler)
-			r.Get("/", app.fetchWorkoutsHandler)
+			r.With(catalogRead).Get("/", app.fetchWorkoutsHandler)
+			r.With(catalogWrite...).Post("/", app.createWorkoutHandler)
+
 			r.Route("/{workoutId}", func(r chi.Router) {
-				r.Use(app.workoutContextMiddleware)
-				r.Get("/", app.getWorkoutHandler)
+				r.With(catalogRead, app.workoutContextMiddleware).Get("/", app.getWorkoutHandler)
+				r.With(catalogRead, app.workoutContextMiddleware).Post("/strength-estimate", app.estimateStrengthHandler)
+
+				r.Group(func(r chi.Router) {
+					r.Use(catalogWrite...)
+					r.Use(app.workoutContextMiddleware)
+					r.Patch("/", app.updateWorkoutHandler)
+					r.Put("/strength-standards", app.updateStrengthStandardHandler)
+					r.With(app.requireStepUp).Delete("/", app.deleteWorkoutHandler)
+				})
+			})
+		})
+
+		// current user endpoints
+		r.Route("/me", func(r chi.Router) {
+			r.Use(app.AuthTokenMiddleware, app.requireUser)
+			r.Get("/profile", app.getProfileHandler)
+			r.Patch("/profile", app.updateProfileHandler)
+		})
+
+		// routines endpoints
+		r.Route("/routines", func(r chi.Router) {
+			r.Use(app.AuthTokenMiddleware, app.requireUser)
+			r.Get("/", app.fetchRoutinesHandler)
+			r.Post("/", app.createRoutineHandler)
+
+			r.Route("/{routineId}", func(r chi.Router) {
+				r.Use(app.routineContextMiddleware)
+				r.Get("/", app.getRoutineHandler)
+				r.Patch("/", app.updateRoutineHandler)
+				r.Delete("/", app.deleteRoutineHandler)
+			})
+		})
+
+		// sessions endpoints
+		r.Route("/sessions", func(r chi.Router) {
+			r.Use(app.AuthTokenMiddleware, app.requireUser)
+			r.Get("/", app.fetchSessionsHandler)
+			r.Post("/", app.createSessionHandler)
+
+			r.Route("/{sessionId}", func(r chi.Router) {
+				r.Use(app.sessionContextMiddleware)
+				r.Get("/", app.getSessionHandler)
+				r.Post("/sets", app.logSetHandler)
+				r.Patch("/finish", app.finishSessionHandler)
 			})
 		})
+
+		// circuits endpoints
+		r.Route("/circuits", func(r chi.Router) {
+			r.Use(app.AuthTokenMiddleware, app.requireUser)
+			r.Get("/", app.fetchCircuitsHandler)
+			r.Post("/", app.createCircuitHandler)
+
+			r.Route("/{circuitId}", func(r chi.Router) {
+				r.Use(app.circuitContextMiddleware)
+				r.Get("/", app.getCircuitHandler)
+				r.Get("/timeline", app.getCircuitTimelineHandler)
+
+				r.Group(func(r chi.Router) {
+					r.Use(app.requireCircuitOwner)
+					r.Patch("/", app.updateCircuitHandler)
+					r.Delete("/", app.deleteCircuitHandler)
+				})
+			})
+		})
+
+		// api keys endpoints
+		r.Route("/api-keys", func(r chi.Router) {
+			r.Use(app.AuthTokenMiddleware, app.requirePermission(permAPIKeysManage))
+			r.Get("/", app.fetchAPIKeysHandler)
+			r.Post("/", app.createAPIKeyHandler)
+
+			r.Route("/{keyId}", func(r chi.Router) {
+				r.Use(app.apiKeyContextMiddleware)
+				r.Get("/", app.getAPIKeyHandler)
+				r.Patch("/", app.updateAPIKeyHandler)
+				r.Delete("/", app.revokeAPIKeyHandler)
+			})
+		})
+
+		// users endpoints
+		r.Route("/users/{userId}", func(r chi.Router) {
+			r.Use(app.AuthTokenMiddleware, app.requirePermission(permUsersManage))
+			r.Put("/role", app.updateUserRoleHandler)
+		})
+
+		// admin endpoints
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(app.AuthTokenMiddleware, app.requirePermission(permLogsManage))
+			r.Get("/log-level", app.getLogLevelHandler)
+			r.Put("/log-level", app.updateLogLevelHandler)
+		})
 	})
 
 	return r
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	store := store.Storage{
+		BodyParts: new(mocks.MockBodyPartStore),
+		Workouts:  new(mocks.MockWorkoutStore),
+		Users:     new(mocks.MockUserStore),
+		APIKeys:   new(mocks.MockAPIKeyStore),
+	}
+
+	app := newTestApplication(t, store)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		key                string
+		req                *http.Request
+		expectedStatusCode int
+	}{
+		{"should return 200 - read scope on read", mocks.MockReadOnlyAPIKey, newGetBodyPartsRequest(), http.StatusOK},
+		{"should return 201 - write scope on create", mocks.MockAPIKey, newPostBodyPartRequest([]byte(`{"name": "chest", "image_url": "https://example.com/chest.png"}`)), http.StatusCreated},
+		{"should return 403 - write scope on delete without totp", mocks.MockAPIKey, stepUp(t, newDeleteWorkoutRequest(1)), http.StatusForbidden},
+		{"should return 403 - read scope on delete", mocks.MockReadOnlyAPIKey, newDeleteWorkoutRequest(1), http.StatusForbidden},
+		{"should return 401 - unknown key", "mfit_deadbeef_nope", newGetBodyPartsRequest(), http.StatusUnauthorized},
+		{"should return 401 - revoked key", mocks.MockRevokedAPIKey, newGetBodyPartsRequest(), http.StatusUnauthorized},
+		{"should return 401 - expired key", mocks.MockExpiredAPIKey, newGetBodyPartsRequest(), http.StatusUnauthorized},
+		{"should return 403 - key managing keys", mocks.MockAPIKey, newAPIKeysRequest(http.MethodGet, "", nil), http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Header.Set("X-API-Key", tt.key)
+
+			res := execRequest(mux, tt.req)
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+		})
+	}
+}
+
+func TestManageAPIKeys(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		Users:   new(mocks.MockUserStore),
+		APIKeys: new(mocks.MockAPIKeyStore),
+	})
+	mux := app.mount()
+
+	t.Run("should return 201 - create returns key once", func(t *testing.T) {
+		payload := []byte(`{"name": "kiosk", "scopes": ["catalog:read"]}`)
+		req := authorize(t, app, newAPIKeysRequest(http.MethodPost, "", payload), mocks.MockAdminID)
+
+		res := execRequest(mux, req)
+
+		assertStatusCode(t, res.Code, http.StatusCreated)
+
+		var body struct {
+			Data struct {
+				Key       string   `json:"key"`
+				Prefix    string   `json:"prefix"`
+				Scopes    []string `json:"scopes"`
+				CreatedBy int64    `json:"created_by"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.HasPrefix(body.Data.Key, body.Data.Prefix+"_") {
+			t.Errorf("key %q does not match prefix %q", body.Data.Key, body.Data.Prefix)
+		}
+
+		if body.Data.CreatedBy != mocks.MockAdminID {
+			t.Errorf("got created_by %d want %d", body.Data.CreatedBy, mocks.MockAdminID)
+		}
+	})
+
+	tests := []struct {
+		name               string
+		userID             int64
+		method             string
+		path               string
+		payload            string
+		expectedStatusCode int
+	}{
+		{"should return 400 - unknown scope", mocks.MockAdminID, http.MethodPost, "", `{"name": "kiosk", "scopes": ["catalog:delete"]}`, http.StatusBadRequest},
+		{"should return 400 - past expiry", mocks.MockAdminID, http.MethodPost, "", `{"name": "kiosk", "scopes": ["catalog:read"], "expires_at": "2001-01-01T00:00:00Z"}`, http.StatusBadRequest},
+		{"should return 200 - list", mocks.MockAdminID, http.MethodGet, "", "", http.StatusOK},
+		{"should return 200 - update expiry", mocks.MockAdminID, http.MethodPatch, "/2", `{"expires_at": "2030-01-01T00:00:00Z"}`, http.StatusOK},
+		{"should return 400 - update expiry to the past", mocks.MockAdminID, http.MethodPatch, "/2", `{"expires_at": "2020-01-01T00:00:00Z"}`, http.StatusBadRequest},
+		{"should return 409 - update expiry of a revoked key", mocks.MockAdminID, http.MethodPatch, "/3", `{"expires_at": "2030-01-01T00:00:00Z"}`, http.StatusConflict},
+		{"should return 204 - revoke", mocks.MockAdminID, http.MethodDelete, "/2", "", http.StatusNoContent},
+		{"should return 404 - revoke twice", mocks.MockAdminID, http.MethodDelete, "/3", "", http.StatusNotFound},
+		{"should return 404 - unknown key", mocks.MockAdminID, http.MethodGet, "/99", "", http.StatusNotFound},
+		{"should return 403 - member", mocks.MockMemberID, http.MethodGet, "", "", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload []byte
+			if tt.payload != "" {
+				payload = []byte(tt.payload)
+			}
+
+			req := authorize(t, app, newAPIKeysRequest(tt.method, tt.path, payload), tt.userID)
+
+			res := execRequest(mux, req)
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+		})
+	}
+}
+
+func newAPIKeysRequest(method, path string, payload []byte) *http.Request {
+	req := newJSONRequest(method, newCollectionPath("api-keys"+path), bytes.NewReader(payload))
+	return req
+}
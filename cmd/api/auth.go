@@ -0,0 +1,353 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/auth"
+	"github.com/JerryLegend254/mfit_api/internal/mailer"
+	"github.com/JerryLegend254/mfit_api/internal/metrics"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+var errInvalidOneTimeToken = errors.New("invalid or expired token")
+
+type RegisterUserPayload struct {
+	Username string `json:"username" validate:"required,max=40"`
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+func (p *RegisterUserPayload) normalize() {
+	p.Username = strings.TrimSpace(p.Username)
+	p.Email = strings.TrimSpace(p.Email)
+}
+
+// RegisterUser godoc
+//
+//	@Summary		Registers a user
+//	@Description	Registers an inactive user and emails them an activation link
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		RegisterUserPayload	true	"User credentials"
+//	@Success		201		{object}	store.User
+//	@Failure		400		{object}	Problem
+//	@Failure		409		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Router			/auth/register [post]
+func (app *application) registerUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	payload, err := decodeJSON[RegisterUserPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	user := &store.User{
+		Username: payload.Username,
+		Email:    payload.Email,
+	}
+
+	if err := user.Password.Set(payload.Password); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	token, hash, err := auth.GenerateOneTimeToken()
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.store.Users.CreateAndInvite(ctx, user, hash, app.config.mail.exp); err != nil {
+		switch err {
+		case store.ErrDuplicateEmail, store.ErrDuplicateUsername:
+			app.conflictError(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	vars := struct {
+		Username      string
+		ActivationURL string
+		ExpiresIn     string
+	}{
+		Username:      user.Username,
+		ActivationURL: app.frontendLink("/activate", token),
+		ExpiresIn:     formatExpiry(app.config.mail.exp),
+	}
+
+	if err := app.mailer.Send(mailer.UserWelcomeTemplate, user.Username, user.Email, vars); err != nil {
+		// without the email the account can never be activated, so undo it
+		if err := app.store.Users.Delete(ctx, user.ID); err != nil {
+			app.requestLogger(r).Errorw("failed to delete user after invitation failure", "user_id", user.ID, "error", err.Error())
+		}
+
+		app.internalServerError(w, r, err)
+		return
+	}
+	metrics.UsersRegistered.Inc()
+
+	if err := app.jsonResponse(w, http.StatusCreated, user); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// LoginUserPayload leaves the password policy to registration, passwords set
+// under an older policy must still be able to log in.
+type LoginUserPayload struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required,max=72"`
+}
+
+func (p *LoginUserPayload) normalize() {
+	p.Email = strings.TrimSpace(p.Email)
+}
+
+type AuthTokenResponse struct {
+	Token     string      `json:"token"`
+	ExpiresAt time.Time   `json:"expires_at"`
+	User      *store.User `json:"user"`
+}
+
+// LoginUser godoc
+//
+//	@Summary		Logs a user in
+//	@Description	Verifies a user's email and password and issues a bearer access token
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		LoginUserPayload	true	"User credentials"
+//	@Success		200		{object}	AuthTokenResponse
+//	@Failure		400		{object}	Problem
+//	@Failure		401		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Router			/auth/login [post]
+func (app *application) loginUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	payload, err := decodeJSON[LoginUserPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	user, err := app.store.Users.GetByEmail(ctx, payload.Email)
+	if err != nil {
+		switch err {
+		case store.ErrNotFound:
+			store.CompareDummyPassword(payload.Password)
+			app.invalidCredentials(w, r)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := user.Password.Compare(payload.Password); err != nil {
+		app.invalidCredentials(w, r)
+		return
+	}
+
+	if !user.IsActive {
+		app.forbiddenError(w, r, "account is not activated")
+		return
+	}
+
+	token, expiresAt, err := app.authenticator.GenerateToken(strconv.FormatInt(user.ID, 10))
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	res := AuthTokenResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		User:      user,
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, res); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+type ActivateUserPayload struct {
+	Token string `json:"token" validate:"required,max=255"`
+}
+
+// ActivateUser godoc
+//
+//	@Summary		Activates a user
+//	@Description	Activates a user with the token from their invitation email
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		ActivateUserPayload	true	"Activation token"
+//	@Success		200		{object}	store.User
+//	@Failure		400		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Router			/auth/activate [post]
+func (app *application) activateUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	payload, err := decodeJSON[ActivateUserPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	user, err := app.store.Users.Activate(ctx, auth.HashOneTimeToken(payload.Token))
+	if err != nil {
+		switch err {
+		case store.ErrNotFound:
+			app.badRequest(w, r, errInvalidOneTimeToken)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, user); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+type ForgotPasswordPayload struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+func (p *ForgotPasswordPayload) normalize() {
+	p.Email = strings.TrimSpace(p.Email)
+}
+
+// ForgotPassword godoc
+//
+//	@Summary		Requests a password reset
+//	@Description	Emails a password reset link to an active account. The response is the same whether or not the account exists
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		ForgotPasswordPayload	true	"Account email"
+//	@Success		202		{object}	string
+//	@Failure		400		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Router			/auth/password/forgot [post]
+func (app *application) forgotPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	payload, err := decodeJSON[ForgotPasswordPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	user, err := app.store.Users.GetByEmail(ctx, payload.Email)
+	switch {
+	case err == store.ErrNotFound:
+		// answer as if it exists so the endpoint can't be used to probe emails
+	case err != nil:
+		app.internalServerError(w, r, err)
+		return
+	case user.IsActive:
+		if err := app.sendPasswordReset(ctx, user); err != nil {
+			app.internalServerError(w, r, err)
+			return
+		}
+	}
+
+	msg := "if an active account uses that email, a reset link has been sent"
+	if err := app.jsonResponse(w, http.StatusAccepted, msg); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+func (app *application) sendPasswordReset(ctx context.Context, user *store.User) error {
+	token, hash, err := auth.GenerateOneTimeToken()
+	if err != nil {
+		return err
+	}
+
+	if err := app.store.Users.CreateToken(ctx, user.ID, store.TokenScopePasswordReset, hash, app.config.mail.resetExp); err != nil {
+		return err
+	}
+
+	vars := struct {
+		Username  string
+		ResetURL  string
+		ExpiresIn string
+	}{
+		Username:  user.Username,
+		ResetURL:  app.frontendLink("/reset-password", token),
+		ExpiresIn: formatExpiry(app.config.mail.resetExp),
+	}
+
+	return app.mailer.Send(mailer.PasswordResetTemplate, user.Username, user.Email, vars)
+}
+
+type ResetPasswordPayload struct {
+	Token    string `json:"token" validate:"required,max=255"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+// ResetPassword godoc
+//
+//	@Summary		Resets a password
+//	@Description	Sets a new password with the token from a password reset email
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		ResetPasswordPayload	true	"Reset token and new password"
+//	@Success		200		{object}	store.User
+//	@Failure		400		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Router			/auth/password/reset [post]
+func (app *application) resetPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	payload, err := decodeJSON[ResetPasswordPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	var user store.User
+	if err := user.Password.Set(payload.Password); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.store.Users.ResetPassword(ctx, auth.HashOneTimeToken(payload.Token), &user); err != nil {
+		switch err {
+		case store.ErrNotFound:
+			app.badRequest(w, r, errInvalidOneTimeToken)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, &user); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+func (app *application) frontendLink(path, token string) string {
+	return fmt.Sprintf("%s%s?token=%s", app.config.frontendURL, path, url.QueryEscape(token))
+}
+
+// formatExpiry renders a token lifetime for emails, e.g. "72 hours".
+func formatExpiry(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		if d == time.Hour {
+			return "1 hour"
+		}
+		return fmt.Sprintf("%d hours", d/time.Hour)
+	}
+	return fmt.Sprintf("%d minutes", d/time.Minute)
+}
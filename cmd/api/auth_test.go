@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/mailer"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+var (
+	RegisterUrl       = newCollectionPath("auth/register")
+	LoginUrl          = newCollectionPath("auth/login")
+	ActivateUrl       = newCollectionPath("auth/activate")
+	ForgotPasswordUrl = newCollectionPath("auth/password/forgot")
+	ResetPasswordUrl  = newCollectionPath("auth/password/reset")
+)
+
+func TestRegisterUser(t *testing.T) {
+	store := store.Storage{
+		Users: new(mocks.MockUserStore),
+	}
+
+	app := newTestApplication(t, store)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		payload            []byte
+		expectedStatusCode int
+	}{
+		{"should return 400 - empty body", []byte(`{}`), http.StatusBadRequest},
+		{"should return 400 - invalid email", []byte(`{"username": "new", "email": "new", "password": "password123"}`), http.StatusBadRequest},
+		{"should return 400 - short password", []byte(`{"username": "new", "email": "new@example.com", "password": "short"}`), http.StatusBadRequest},
+		{"should return 409 - duplicate email", []byte(`{"username": "new", "email": "test@example.com", "password": "password123"}`), http.StatusConflict},
+		{"should return 201", []byte(`{"username": "new", "email": "new@example.com", "password": "password123"}`), http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(http.MethodPost, RegisterUrl, bytes.NewReader(tt.payload))
+
+			res := execRequest(mux, req)
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			assertContentType(t, res.Header().Get("content-type"), contentTypeFor(res.Code))
+
+			if bytes.Contains(res.Body.Bytes(), []byte("password123")) {
+				t.Errorf("response leaks the password: %s", res.Body)
+			}
+		})
+	}
+
+	t.Run("should email an activation link", func(t *testing.T) {
+		sent := app.mailer.(*mailer.FileMailer).Sent()
+		if len(sent) != 1 {
+			t.Fatalf("got %d emails want 1", len(sent))
+		}
+
+		if sent[0].To != "new@example.com" || !strings.Contains(sent[0].Text, "http://localhost:5173/activate?token=") {
+			t.Errorf("unexpected invitation %+v", sent[0])
+		}
+	})
+}
+
+func TestLoginUser(t *testing.T) {
+	store := store.Storage{
+		Users: new(mocks.MockUserStore),
+	}
+
+	app := newTestApplication(t, store)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		payload            []byte
+		expectedStatusCode int
+	}{
+		{"should return 400 - missing password", []byte(`{"email": "test@example.com"}`), http.StatusBadRequest},
+		{"should return 401 - unknown email", []byte(`{"email": "nobody@example.com", "password": "password123"}`), http.StatusUnauthorized},
+		{"should return 401 - wrong password", []byte(`{"email": "test@example.com", "password": "wrongpassword"}`), http.StatusUnauthorized},
+		{"should return 401 - short password", []byte(`{"email": "test@example.com", "password": "pass"}`), http.StatusUnauthorized},
+		{"should return 403 - not activated", []byte(`{"email": "inactive@example.com", "password": "password123"}`), http.StatusForbidden},
+		{"should return 200", []byte(`{"email": "test@example.com", "password": "password123"}`), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(http.MethodPost, LoginUrl, bytes.NewReader(tt.payload))
+
+			res := execRequest(mux, req)
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			assertContentType(t, res.Header().Get("content-type"), contentTypeFor(res.Code))
+		})
+	}
+
+	t.Run("issued token authenticates", func(t *testing.T) {
+		req := newJSONRequest(http.MethodPost, LoginUrl, bytes.NewReader([]byte(`{"email": "test@example.com", "password": "password123"}`)))
+
+		res := execRequest(mux, req)
+
+		var body struct {
+			Data AuthTokenResponse `json:"data"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+
+		subject, err := app.authenticator.ValidateToken(body.Data.Token)
+		if err != nil {
+			t.Fatalf("issued token is invalid: %v", err)
+		}
+
+		if subject != "1" {
+			t.Errorf("got subject %q want %q", subject, "1")
+		}
+	})
+}
+
+func TestActivateUser(t *testing.T) {
+	app := newTestApplication(t, store.Storage{Users: new(mocks.MockUserStore)})
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		payload            []byte
+		expectedStatusCode int
+	}{
+		{"should return 400 - missing token", []byte(`{}`), http.StatusBadRequest},
+		{"should return 400 - unknown token", []byte(`{"token": "nope"}`), http.StatusBadRequest},
+		{"should return 200", []byte(`{"token": "` + mocks.MockActivationToken + `"}`), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(http.MethodPost, ActivateUrl, bytes.NewReader(tt.payload))
+
+			res := execRequest(mux, req)
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+		})
+	}
+}
+
+func TestForgotPassword(t *testing.T) {
+	tests := []struct {
+		name               string
+		payload            []byte
+		expectedStatusCode int
+		expectedEmails     int
+	}{
+		{"should return 400 - invalid email", []byte(`{"email": "test"}`), http.StatusBadRequest, 0},
+		{"should return 202 - unknown email", []byte(`{"email": "nobody@example.com"}`), http.StatusAccepted, 0},
+		{"should return 202 - inactive account", []byte(`{"email": "inactive@example.com"}`), http.StatusAccepted, 0},
+		{"should return 202 - active account", []byte(`{"email": "test@example.com"}`), http.StatusAccepted, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApplication(t, store.Storage{Users: new(mocks.MockUserStore)})
+			mux := app.mount()
+
+			req := newJSONRequest(http.MethodPost, ForgotPasswordUrl, bytes.NewReader(tt.payload))
+
+			res := execRequest(mux, req)
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			sent := app.mailer.(*mailer.FileMailer).Sent()
+			if len(sent) != tt.expectedEmails {
+				t.Fatalf("got %d emails want %d", len(sent), tt.expectedEmails)
+			}
+
+			if tt.expectedEmails > 0 && !strings.Contains(sent[0].Text, "/reset-password?token=") {
+				t.Errorf("email is missing the reset link: %s", sent[0].Text)
+			}
+		})
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	app := newTestApplication(t, store.Storage{Users: new(mocks.MockUserStore)})
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		payload            []byte
+		expectedStatusCode int
+	}{
+		{"should return 400 - short password", []byte(`{"token": "` + mocks.MockResetToken + `", "password": "short"}`), http.StatusBadRequest},
+		{"should return 400 - unknown token", []byte(`{"token": "nope", "password": "newpassword"}`), http.StatusBadRequest},
+		{"should return 200", []byte(`{"token": "` + mocks.MockResetToken + `", "password": "newpassword"}`), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(http.MethodPost, ResetPasswordUrl, bytes.NewReader(tt.payload))
+
+			res := execRequest(mux, req)
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			if bytes.Contains(res.Body.Bytes(), []byte("newpassword")) {
+				t.Errorf("response leaks the password: %s", res.Body)
+			}
+		})
+	}
+}
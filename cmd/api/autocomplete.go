@@ -0,0 +1,45 @@
+package main
+
+import "net/http"
+
+// Autocomplete godoc
+//
+//	@Summary		Suggest catalog names
+//	@Description	Typo-tolerant suggestions for workout, target, equipment and body part names
+//	@Tags			search
+//	@Accept			json
+//	@Produce		json
+//	@Param			q		query		string	true	"Partial name as typed"
+//	@Param			types	query		string	false	"Comma separated suggestion types"		default(workout,target,equipment,bodypart)
+//	@Param			limit	query		int		false	"Maximum number of suggestions (1-25)"	default(10)
+//	@Success		200		{object}	[]store.Suggestion
+//	@Failure		400		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/autocomplete [get]
+func (app *application) autocompleteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	aq, err := parseAutocompleteQuery(r)
+	if err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(aq); err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	suggestions, err := app.store.Autocomplete.Suggest(ctx, aq)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err = app.jsonResponse(w, http.StatusOK, suggestions); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
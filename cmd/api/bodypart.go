@@ -2,10 +2,10 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/JerryLegend254/mfit_api/internal/store"
 	"github.com/go-chi/chi/v5"
@@ -20,6 +20,11 @@ type CreateBodyPartPayload struct {
 	ImageUrl string `json:"image_url" validate:"required,max=255"`
 }
 
+func (p *CreateBodyPartPayload) normalize() {
+	p.Name = strings.TrimSpace(p.Name)
+	p.ImageUrl = strings.TrimSpace(p.ImageUrl)
+}
+
 // CreateBodyPart godoc
 //
 //	@Summary		Creates a body part
@@ -29,23 +34,19 @@ type CreateBodyPartPayload struct {
 //	@Produce		json
 //	@Param			payload	body		CreateBodyPartPayload	true	"BodyPart payload"
 //	@Success		201		{object}	store.BodyPart
-//	@Failure		400		{object}	error
-//	@Failure		403		{object}	error
-//	@Failure		500		{object}	error
+//	@Failure		400		{object}	Problem
+//	@Failure		409		{object}	Problem
+//	@Failure		422		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		500		{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/bodyparts [post]
 func (app *application) createBodyPartHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	var payload CreateBodyPartPayload
-
-	err := json.NewDecoder(r.Body).Decode(&payload)
+	payload, err := decodeJSON[CreateBodyPartPayload](w, r)
 	if err != nil {
-		app.badRequest(w, r, err)
-		return
-	}
-
-	if err := Validate.Struct(payload); err != nil {
-		app.badRequest(w, r, err)
+		app.decodeError(w, r, err)
 		return
 	}
 
@@ -55,12 +56,7 @@ func (app *application) createBodyPartHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	if err = app.store.BodyParts.Create(ctx, &bodyPart); err != nil {
-		switch err {
-		case store.ErrDuplicate:
-			app.conflictError(w, r, store.ErrDuplicateName)
-		default:
-			app.internalServerError(w, r, err)
-		}
+		app.storeError(w, r, err)
 		return
 	}
 
@@ -72,25 +68,41 @@ func (app *application) createBodyPartHandler(w http.ResponseWriter, r *http.Req
 // GetAllBodyParts godoc
 //
 //	@Summary		Fetch all body parts
-//	@Description	Fetch all body parts
+//	@Description	Fetch a page of body parts
 //	@Tags			body parts
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	[]store.BodyPart
-//	@Failure		403	{object}	error
-//	@Failure		500	{object}	error
+//	@Param			limit	query		int		false	"Page size (1-100)"	default(20)
+//	@Param			cursor	query		string	false	"Opaque cursor from a previous page"
+//	@Param			sort	query		string	false	"Sort field, prefix with - for descending"	Enums(id, -id, name, -name)
+//	@Success		200		{object}	[]store.BodyPart
+//	@Failure		400		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		500		{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/bodyparts [get]
 func (app *application) fetchBodyPartsHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	bodyParts, err := app.store.BodyParts.GetAll(ctx)
+	page, err := parsePaginatedQuery(r, store.BodyPartSorts)
+	if err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(page); err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	bodyParts, meta, err := app.store.BodyParts.GetAll(ctx, page)
 	if err != nil {
 		app.internalServerError(w, r, err)
 		return
 	}
 
-	if err = app.jsonResponse(w, http.StatusOK, bodyParts); err != nil {
+	if err = app.paginatedJSONResponse(w, http.StatusOK, bodyParts, meta); err != nil {
 		app.internalServerError(w, r, err)
 	}
 }
@@ -104,9 +116,10 @@ func (app *application) fetchBodyPartsHandler(w http.ResponseWriter, r *http.Req
 //	@Produce		json
 //	@Param			bodyPartId	path		int	true	"Body Part ID"
 //	@Success		200			{object}	store.BodyPart
-//	@Failure		404			{object}	error
-//	@Failure		500			{object}	error
+//	@Failure		404			{object}	Problem
+//	@Failure		500			{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/bodyparts/{bodyPartId} [get]
 func (app *application) getBodyPartHandler(w http.ResponseWriter, r *http.Request) {
 	bodyPart := getBodyPartFromContext(r)
@@ -123,13 +136,17 @@ func (app *application) getBodyPartHandler(w http.ResponseWriter, r *http.Reques
 //	@Tags			body parts
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path	int	true	"Body Part ID"
+//	@Param			id			path	int		true	"Body Part ID"
+//	@Param			X-TOTP-Code	header	string	true	"Current TOTP code or an unused recovery code"
 //	@Success		204
-//	@Failure		400	{object}	error
-//	@Failure		401	{object}	error
-//	@Failure		404	{object}	error
-//	@Failure		500	{object}	error
+//	@Failure		400	{object}	Problem
+//	@Failure		401	{object}	Problem
+//	@Failure		403	{object}	Problem
+//	@Failure		404	{object}	Problem
+//	@Failure		429	{object}	Problem
+//	@Failure		500	{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/bodyparts/{bodyPartId} [delete]
 func (app *application) deleteBodyPartHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -153,6 +170,11 @@ type UpdateBodyPartPayload struct {
 	ImageUrl *string `json:"image_url" validate:"omitempty,max=255"`
 }
 
+func (p *UpdateBodyPartPayload) normalize() {
+	trimString(p.Name)
+	trimString(p.ImageUrl)
+}
+
 // UddateBodyPart godoc
 //
 //	@Summary		Update a body part
@@ -163,26 +185,23 @@ type UpdateBodyPartPayload struct {
 //	@Param			bodyPartId	path		int						true	"Body Part ID"
 //	@Param			bodyPartId	body		UpdateBodyPartPayload	true	"Body Part ID"
 //	@Success		200			{object}	store.BodyPart
-//	@Failure		400			{object}	error
-//	@Failure		401			{object}	error
-//	@Failure		404			{object}	error
-//	@Failure		500			{object}	error
+//	@Failure		400			{object}	Problem
+//	@Failure		401			{object}	Problem
+//	@Failure		404			{object}	Problem
+//	@Failure		409			{object}	Problem
+//	@Failure		422			{object}	Problem
+//	@Failure		500			{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/bodyparts/{bodyPartId} [patch]
 func (app *application) updateBodyPartHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	bodyPart := getBodyPartFromContext(r)
 
-	var payload UpdateBodyPartPayload
-
-	if err := readJSON(w, r, &payload); err != nil {
-		app.badRequest(w, r, err)
-		return
-	}
-
-	if err := Validate.Struct(payload); err != nil {
-		app.badRequest(w, r, err)
+	payload, err := decodeJSON[UpdateBodyPartPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
 		return
 	}
 
@@ -195,7 +214,7 @@ func (app *application) updateBodyPartHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	if err := app.store.BodyParts.Update(ctx, bodyPart); err != nil {
-		app.internalServerError(w, r, err)
+		app.storeError(w, r, err)
 		return
 	}
 
@@ -19,14 +19,16 @@ func TestCreateBodyPart(t *testing.T) {
 
 	store := store.Storage{
 		BodyParts: mockBodyPartStore,
+		Users:     new(mocks.MockUserStore),
 	}
 
 	app := newTestApplication(t, store)
 	mux := app.mount()
 
 	type response struct {
-		expectedStatusCode int
-		expectedBody       []byte
+		expectedStatusCode  int
+		expectedBody        []byte
+		expectedContentType string
 	}
 	tests := []struct {
 		name     string
@@ -37,40 +39,40 @@ func TestCreateBodyPart(t *testing.T) {
 			[]byte(`{}`),
 			response{
 				http.StatusBadRequest,
-				func() []byte {
-					res := fmt.Sprintf(`{"error":%q}`, ErrBadRequest.Error())
-					return []byte(res)
-				}(),
+				[]byte(`{"type": "urn:mfit:problem:validation", "title": "Validation failed", "status": 400, "request_id": "test-request", "fields": [
+					{"field": "name", "tag": "required", "message": "is required"},
+					{"field": "image_url", "tag": "required", "message": "is required"}
+				]}`),
+				problemContentType,
 			},
 		},
 		{"should return 400 - missing name",
 			[]byte(`{"image_url": "Test Image Url"}`),
 			response{
 				http.StatusBadRequest,
-				func() []byte {
-					res := fmt.Sprintf(`{"error":%q}`, ErrBadRequest.Error())
-					return []byte(res)
-				}(),
+				[]byte(`{"type": "urn:mfit:problem:validation", "title": "Validation failed", "status": 400, "request_id": "test-request", "fields": [
+					{"field": "name", "tag": "required", "message": "is required"}
+				]}`),
+				problemContentType,
 			},
 		},
 		{"should return 400 - missing image_url",
 			[]byte(`{"name": "Test Name"}`),
 			response{
 				http.StatusBadRequest,
-				func() []byte {
-					res := fmt.Sprintf(`{"error":%q}`, ErrBadRequest.Error())
-					return []byte(res)
-				}(),
+				[]byte(`{"type": "urn:mfit:problem:validation", "title": "Validation failed", "status": 400, "request_id": "test-request", "fields": [
+					{"field": "image_url", "tag": "required", "message": "is required"}
+				]}`),
+				problemContentType,
 			},
 		},
 		{"should return 400 - invalid json",
 			[]byte(`{"name": "Test Name", "image_url": "Test Image Url"`),
 			response{
 				http.StatusBadRequest,
-				func() []byte {
-					res := fmt.Sprintf(`{"error":%q}`, ErrBadRequest.Error())
-					return []byte(res)
-				}(),
+				[]byte(`{"type": "urn:mfit:problem:malformed-body", "title": "Malformed request body", "status": 400, "request_id": "test-request",
+					"detail": "body contains badly-formed JSON"}`),
+				problemContentType,
 			},
 		},
 		{"should return 201",
@@ -78,6 +80,7 @@ func TestCreateBodyPart(t *testing.T) {
 			response{
 				http.StatusCreated,
 				[]byte(`{"data":{"id": 0, "name": "Test Name", "image_url": "Test Image Url"}}`),
+				jsonContentType,
 			},
 		},
 	}
@@ -85,8 +88,9 @@ func TestCreateBodyPart(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// create the response
 			req := newPostBodyPartRequest(tt.payload)
+			req.Header.Set(requestIDHeader, "test-request")
 
-			res := execRequest(mux, req)
+			res := execRequest(mux, authorize(t, app, req, 1))
 
 			// compare the status codes
 			assertStatusCode(t, res.Code, int(tt.response.expectedStatusCode))
@@ -95,11 +99,10 @@ func TestCreateBodyPart(t *testing.T) {
 			assertResponse(t, res.Body, tt.response.expectedBody)
 
 			// compare the content type header
-			assertContentType(t, res.Header().Get("content-type"), jsonContentType)
+			assertContentType(t, res.Header().Get("content-type"), tt.response.expectedContentType)
 
 		})
 	}
-
 }
 
 func TestGetBodyPart(t *testing.T) {
@@ -107,6 +110,7 @@ func TestGetBodyPart(t *testing.T) {
 
 	store := store.Storage{
 		BodyParts: mockBodyPartStore,
+		Users:     new(mocks.MockUserStore),
 	}
 
 	app := newTestApplication(t, store)
@@ -114,7 +118,7 @@ func TestGetBodyPart(t *testing.T) {
 	t.Run("valid route path", func(t *testing.T) {
 		req := newGetBodyPartRequest(1)
 
-		res := execRequest(mux, req)
+		res := execRequest(mux, authorize(t, app, req, 1))
 
 		assertStatusCode(t, res.Code, http.StatusOK)
 
@@ -124,8 +128,44 @@ func TestGetBodyPart(t *testing.T) {
 
 }
 
+func TestFetchBodyParts(t *testing.T) {
+	mockBodyPartStore := new(mocks.MockBodyPartStore)
+
+	store := store.Storage{
+		BodyParts: mockBodyPartStore,
+		Users:     new(mocks.MockUserStore),
+	}
+
+	app := newTestApplication(t, store)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		query              string
+		expectedStatusCode int
+	}{
+		{"should return 200 - default page", "", http.StatusOK},
+		{"should return 200 - sorted page", "?limit=5&sort=-name", http.StatusOK},
+		{"should return 400 - limit too large", "?limit=500", http.StatusBadRequest},
+		{"should return 400 - limit too small", "?limit=0", http.StatusBadRequest},
+		{"should return 400 - unknown sort", "?sort=image_url", http.StatusBadRequest},
+		{"should return 400 - invalid cursor", "?cursor=not-a-cursor", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, BodyPartUrl+tt.query, nil)
+
+			res := execRequest(mux, authorize(t, app, req, 1))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			assertContentType(t, res.Header().Get("content-type"), contentTypeFor(res.Code))
+		})
+	}
+}
+
 func newPostBodyPartRequest(payload []byte) *http.Request {
-	req, _ := http.NewRequest(http.MethodPost, BodyPartUrl, bytes.NewReader(payload))
+	req := newJSONRequest(http.MethodPost, BodyPartUrl, bytes.NewReader(payload))
 	return req
 }
 
@@ -140,7 +180,7 @@ func newGetBodyPartsRequest() *http.Request {
 }
 
 func newPatchBodyPartRequest(id int64, payload []byte) *http.Request {
-	req, _ := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/%d", BodyPartUrl, id), bytes.NewReader(payload))
+	req := newJSONRequest(http.MethodPatch, fmt.Sprintf("%s/%d", BodyPartUrl, id), bytes.NewReader(payload))
 	return req
 }
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
 )
 
 func TestBodyPartIT(t *testing.T) {
@@ -18,6 +19,16 @@ func TestBodyPartIT(t *testing.T) {
 
 	}
 
+	_, err = db.Exec("INSERT INTO users (username, email, password, role) VALUES ($1, $2, $3, $4);", "test", "test@example.com", []byte("hash"), store.RoleAdmin)
+	if err != nil {
+		t.Fatalf("error seeding users table: %v", err)
+	}
+
+	_, err = db.Exec("INSERT INTO user_totp (user_id, secret, confirmed_at) VALUES ($1, $2, NOW());", 1, mocks.MockTOTPSecret)
+	if err != nil {
+		t.Fatalf("error seeding user_totp table: %v", err)
+	}
+
 	s := store.NewStorage(db)
 
 	app := newTestApplication(t, s)
@@ -30,14 +41,14 @@ func TestBodyPartIT(t *testing.T) {
 	}{
 		{"create bodypart", newPostBodyPartRequest([]byte(`{"name": "Test Name", "image_url": "Test Image Url"}`)), []byte(`{"data": {"id": 2, "name": "Test Name", "image_url": "Test Image Url"}}`)},
 		{"get one bodypart", newGetBodyPartRequest(1), []byte(`{"data": {"id": 1, "name": "Test 1", "image_url": "Image Url 1"}}`)},
-		{"get all bodyparts", newGetBodyPartsRequest(), []byte(`{"data": [{"id": 1, "name": "Test 1", "image_url": "Image Url 1"},{"id": 2, "name": "Test Name", "image_url": "Test Image Url"}]}`)},
+		{"get all bodyparts", newGetBodyPartsRequest(), []byte(`{"data": [{"id": 1, "name": "Test 1", "image_url": "Image Url 1"},{"id": 2, "name": "Test Name", "image_url": "Test Image Url"}], "meta": {"limit": 20, "sort": "id", "total": 2}}`)},
 		{"update bodypart", newPatchBodyPartRequest(2, []byte(`{"name": "Update Title", "image_url": "Updated Image Url"}`)), []byte(`{"data": {"id": 2, "name": "Update Title", "image_url": "Updated Image Url"}}`)},
-		{"delete bodypart", newDeleteBodyPartRequest(1), nil},
+		{"delete bodypart", stepUp(t, newDeleteBodyPartRequest(1)), nil},
 	}
 
 	for _, tt := range ts {
 		t.Run(tt.name, func(t *testing.T) {
-			res := execRequest(mux, tt.req)
+			res := execRequest(mux, authorize(t, app, tt.req, 1))
 			assertResponse(t, res.Body, tt.want)
 		})
 	}
@@ -0,0 +1,418 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/go-chi/chi/v5"
+)
+
+type circuitContextKey string
+
+var circuitCtxKey circuitContextKey = "circuit"
+
+// maxSupersetMembers keeps supersets to the usual A1/A2 up to giant sets.
+const maxSupersetMembers = 4
+
+var errInvalidCircuit = errors.New("invalid circuit")
+
+type CircuitMemberPayload struct {
+	WorkoutID   int64 `json:"workout_id" validate:"required,gt=0"`
+	WorkSeconds *int  `json:"work_seconds" validate:"omitnil,gte=1,lte=3600"`
+}
+
+type CreateCircuitPayload struct {
+	Name             string                 `json:"name" validate:"required,max=100"`
+	Kind             string                 `json:"kind" validate:"omitempty,oneof=circuit superset"`
+	Rounds           int                    `json:"rounds" validate:"required,gte=1,lte=50"`
+	WorkSeconds      int                    `json:"work_seconds" validate:"required,gte=1,lte=3600"`
+	RestSeconds      int                    `json:"rest_seconds" validate:"gte=0,lte=600"`
+	RoundRestSeconds int                    `json:"round_rest_seconds" validate:"gte=0,lte=1800"`
+	Members          []CircuitMemberPayload `json:"members" validate:"required,min=2,max=20,dive"`
+}
+
+func (p *CreateCircuitPayload) normalize() {
+	p.Name = strings.TrimSpace(p.Name)
+}
+
+// CircuitTimelineResponse is the schedule a client timer plays back, phases
+// are contiguous and ordered by start_second.
+type CircuitTimelineResponse struct {
+	CircuitID    int64                `json:"circuit_id"`
+	TotalSeconds int                  `json:"total_seconds"`
+	Phases       []store.CircuitPhase `json:"phases"`
+}
+
+// CreateCircuit godoc
+//
+//	@Summary		Creates a circuit
+//	@Description	Creates a circuit or superset template from catalog workouts, members keep the order given
+//	@Tags			circuits
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		CreateCircuitPayload	true	"Circuit payload"
+//	@Success		201		{object}	store.Circuit
+//	@Failure		400		{object}	Problem
+//	@Failure		401		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/circuits [post]
+func (app *application) createCircuitHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := getUserFromContext(r)
+
+	payload, err := decodeJSON[CreateCircuitPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	members, err := app.newCircuitMembers(ctx, payload.Members)
+	if err != nil {
+		app.circuitError(w, r, err)
+		return
+	}
+
+	circuit := &store.Circuit{
+		UserID:           user.ID,
+		Name:             payload.Name,
+		Kind:             payload.Kind,
+		Rounds:           payload.Rounds,
+		WorkSeconds:      payload.WorkSeconds,
+		RestSeconds:      payload.RestSeconds,
+		RoundRestSeconds: payload.RoundRestSeconds,
+		Members:          members,
+	}
+	if circuit.Kind == "" {
+		circuit.Kind = "circuit"
+	}
+
+	if err := validateCircuit(circuit); err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	if err := app.store.Circuits.Create(ctx, circuit); err != nil {
+		app.circuitError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusCreated, circuit); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// FetchCircuits godoc
+//
+//	@Summary		Fetches circuits
+//	@Description	Fetches all circuit and superset templates with their members expanded
+//	@Tags			circuits
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	[]store.Circuit
+//	@Failure		401	{object}	Problem
+//	@Failure		403	{object}	Problem
+//	@Failure		500	{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/circuits [get]
+func (app *application) fetchCircuitsHandler(w http.ResponseWriter, r *http.Request) {
+	circuits, err := app.store.Circuits.GetAll(r.Context())
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, circuits); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// GetCircuit godoc
+//
+//	@Summary		Fetches a circuit
+//	@Description	Fetches a circuit by ID
+//	@Tags			circuits
+//	@Accept			json
+//	@Produce		json
+//	@Param			circuitId	path		int	true	"Circuit ID"
+//	@Success		200			{object}	store.Circuit
+//	@Failure		401			{object}	Problem
+//	@Failure		403			{object}	Problem
+//	@Failure		404			{object}	Problem
+//	@Failure		500			{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/circuits/{circuitId} [get]
+func (app *application) getCircuitHandler(w http.ResponseWriter, r *http.Request) {
+	circuit := getCircuitFromContext(r)
+
+	if err := app.jsonResponse(w, http.StatusOK, circuit); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// GetCircuitTimeline godoc
+//
+//	@Summary		Fetches a circuit's timeline
+//	@Description	Expands a circuit into its work and rest phases with start offsets in seconds
+//	@Tags			circuits
+//	@Accept			json
+//	@Produce		json
+//	@Param			circuitId	path		int	true	"Circuit ID"
+//	@Success		200			{object}	CircuitTimelineResponse
+//	@Failure		401			{object}	Problem
+//	@Failure		403			{object}	Problem
+//	@Failure		404			{object}	Problem
+//	@Failure		500			{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/circuits/{circuitId}/timeline [get]
+func (app *application) getCircuitTimelineHandler(w http.ResponseWriter, r *http.Request) {
+	circuit := getCircuitFromContext(r)
+
+	res := CircuitTimelineResponse{
+		CircuitID: circuit.ID,
+		Phases:    circuit.Timeline(),
+	}
+	if n := len(res.Phases); n > 0 {
+		res.TotalSeconds = res.Phases[n-1].StartSecond + res.Phases[n-1].DurationSeconds
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, res); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// UpdateCircuitPayload replaces all of the circuit's members when members is
+// set.
+type UpdateCircuitPayload struct {
+	Name             *string                 `json:"name" validate:"omitnil,min=1,max=100"`
+	Kind             *string                 `json:"kind" validate:"omitnil,oneof=circuit superset"`
+	Rounds           *int                    `json:"rounds" validate:"omitnil,gte=1,lte=50"`
+	WorkSeconds      *int                    `json:"work_seconds" validate:"omitnil,gte=1,lte=3600"`
+	RestSeconds      *int                    `json:"rest_seconds" validate:"omitnil,gte=0,lte=600"`
+	RoundRestSeconds *int                    `json:"round_rest_seconds" validate:"omitnil,gte=0,lte=1800"`
+	Members          *[]CircuitMemberPayload `json:"members" validate:"omitnil,min=2,max=20,dive"`
+}
+
+func (p *UpdateCircuitPayload) normalize() {
+	trimString(p.Name)
+}
+
+// UpdateCircuit godoc
+//
+//	@Summary		Updates a circuit
+//	@Description	Updates a circuit owned by the current user, a list of members replaces the existing ones
+//	@Tags			circuits
+//	@Accept			json
+//	@Produce		json
+//	@Param			circuitId	path		int						true	"Circuit ID"
+//	@Param			payload		body		UpdateCircuitPayload	true	"Circuit payload"
+//	@Success		200			{object}	store.Circuit
+//	@Failure		400			{object}	Problem
+//	@Failure		401			{object}	Problem
+//	@Failure		403			{object}	Problem
+//	@Failure		404			{object}	Problem
+//	@Failure		500			{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/circuits/{circuitId} [patch]
+func (app *application) updateCircuitHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	circuit := getCircuitFromContext(r)
+
+	payload, err := decodeJSON[UpdateCircuitPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	if payload.Name != nil {
+		circuit.Name = *payload.Name
+	}
+
+	if payload.Kind != nil {
+		circuit.Kind = *payload.Kind
+	}
+
+	if payload.Rounds != nil {
+		circuit.Rounds = *payload.Rounds
+	}
+
+	if payload.WorkSeconds != nil {
+		circuit.WorkSeconds = *payload.WorkSeconds
+	}
+
+	if payload.RestSeconds != nil {
+		circuit.RestSeconds = *payload.RestSeconds
+	}
+
+	if payload.RoundRestSeconds != nil {
+		circuit.RoundRestSeconds = *payload.RoundRestSeconds
+	}
+
+	if payload.Members != nil {
+		members, err := app.newCircuitMembers(ctx, *payload.Members)
+		if err != nil {
+			app.circuitError(w, r, err)
+			return
+		}
+		circuit.Members = members
+	}
+
+	if err := validateCircuit(circuit); err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	if err := app.store.Circuits.Update(ctx, circuit); err != nil {
+		switch err {
+		case store.ErrNotFound:
+			app.notFound(w, r)
+		default:
+			app.circuitError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, circuit); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// DeleteCircuit godoc
+//
+//	@Summary		Deletes a circuit
+//	@Description	Deletes a circuit owned by the current user
+//	@Tags			circuits
+//	@Accept			json
+//	@Produce		json
+//	@Param			circuitId	path	int	true	"Circuit ID"
+//	@Success		204
+//	@Failure		401	{object}	Problem
+//	@Failure		403	{object}	Problem
+//	@Failure		404	{object}	Problem
+//	@Failure		500	{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/circuits/{circuitId} [delete]
+func (app *application) deleteCircuitHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	circuit := getCircuitFromContext(r)
+
+	if err := app.store.Circuits.Delete(ctx, circuit.ID); err != nil {
+		switch err {
+		case store.ErrNotFound:
+			app.notFound(w, r)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// newCircuitMembers checks that every member's workout exists and returns
+// the members with their workouts attached.
+func (app *application) newCircuitMembers(ctx context.Context, payload []CircuitMemberPayload) ([]store.CircuitMember, error) {
+	ids := make([]int64, len(payload))
+	for i, p := range payload {
+		ids[i] = p.WorkoutID
+	}
+
+	workouts, err := app.store.Workouts.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*store.PresentableWorkout, len(workouts))
+	for i := range workouts {
+		byID[workouts[i].ID] = &workouts[i]
+	}
+
+	members := make([]store.CircuitMember, len(payload))
+	for i, p := range payload {
+		workout, ok := byID[p.WorkoutID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %w", store.ErrUnknownWorkout, newFieldError(fmt.Sprintf("members[%d].workout_id", i), "exists", "is not a known workout"))
+		}
+
+		members[i] = store.CircuitMember{
+			Position:    i + 1,
+			WorkoutID:   p.WorkoutID,
+			WorkSeconds: p.WorkSeconds,
+			Workout:     workout,
+		}
+	}
+
+	return members, nil
+}
+
+// validateCircuit checks the rules that span several fields once a create or
+// update payload has been applied.
+func validateCircuit(circuit *store.Circuit) error {
+	if circuit.Kind == "superset" && len(circuit.Members) > maxSupersetMembers {
+		return fmt.Errorf("%w: %w", errInvalidCircuit, newFieldError("members", "max", fmt.Sprintf("must be at most %d items for a superset", maxSupersetMembers)))
+	}
+	return nil
+}
+
+// circuitError reports unknown workouts as bad requests and anything else as
+// a store error.
+func (app *application) circuitError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, store.ErrUnknownWorkout):
+		app.badRequest(w, r, err)
+	default:
+		app.storeError(w, r, err)
+	}
+}
+
+func (app *application) circuitContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id := chi.URLParam(r, "circuitId")
+
+		intId, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			app.badRequest(w, r, errors.New("invalid circuit id"))
+			return
+		}
+
+		circuit, err := app.store.Circuits.GetByID(ctx, intId)
+		if err != nil {
+			switch err {
+			case store.ErrNotFound:
+				app.notFound(w, r)
+			default:
+				app.internalServerError(w, r, err)
+			}
+			return
+		}
+
+		ctx = context.WithValue(ctx, circuitCtxKey, circuit)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requireCircuitOwner lets only the circuit's creator change it, circuits
+// are readable by everyone.
+func (app *application) requireCircuitOwner(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if getCircuitFromContext(r).UserID != getUserFromContext(r).ID {
+			app.forbiddenError(w, r, "only the circuit's creator can change it")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func getCircuitFromContext(r *http.Request) *store.Circuit {
+	circuit, _ := r.Context().Value(circuitCtxKey).(*store.Circuit)
+	return circuit
+}
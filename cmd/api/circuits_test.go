@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+var CircuitsUrl = newCollectionPath("circuits")
+
+func newCircuitTestApplication(t testing.TB) *application {
+	return newTestApplication(t, store.Storage{
+		Users:    new(mocks.MockUserStore),
+		Workouts: new(mocks.MockWorkoutStore),
+		Circuits: new(mocks.MockCircuitStore),
+	})
+}
+
+func TestCreateCircuit(t *testing.T) {
+	app := newCircuitTestApplication(t)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		payload            string
+		expectedStatusCode int
+	}{
+		{"should return 201 - circuit", `{"name": "HIIT", "rounds": 3, "work_seconds": 40, "rest_seconds": 20, "members": [{"workout_id": 1}, {"workout_id": 2, "work_seconds": 30}]}`, http.StatusCreated},
+		{"should return 201 - superset", `{"name": "A1/A2", "kind": "superset", "rounds": 4, "work_seconds": 45, "round_rest_seconds": 90, "members": [{"workout_id": 1}, {"workout_id": 2}]}`, http.StatusCreated},
+		{"should return 400 - single member", `{"name": "HIIT", "rounds": 3, "work_seconds": 40, "members": [{"workout_id": 1}]}`, http.StatusBadRequest},
+		{"should return 400 - zero rounds", `{"name": "HIIT", "rounds": 0, "work_seconds": 40, "members": [{"workout_id": 1}, {"workout_id": 2}]}`, http.StatusBadRequest},
+		{"should return 400 - unknown kind", `{"name": "HIIT", "kind": "emom", "rounds": 3, "work_seconds": 40, "members": [{"workout_id": 1}, {"workout_id": 2}]}`, http.StatusBadRequest},
+		{"should return 400 - oversized superset", `{"name": "Giant", "kind": "superset", "rounds": 3, "work_seconds": 40, "members": [{"workout_id": 1}, {"workout_id": 2}, {"workout_id": 3}, {"workout_id": 4}, {"workout_id": 5}]}`, http.StatusBadRequest},
+		{"should return 400 - unknown workout", fmt.Sprintf(`{"name": "HIIT", "rounds": 3, "work_seconds": 40, "members": [{"workout_id": 1}, {"workout_id": %d}]}`, mocks.MockMissingWorkoutID), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(http.MethodPost, CircuitsUrl, bytes.NewBufferString(tt.payload))
+
+			res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+		})
+	}
+}
+
+func TestCircuitOwnership(t *testing.T) {
+	app := newCircuitTestApplication(t)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		method             string
+		userID             int64
+		payload            string
+		expectedStatusCode int
+	}{
+		{"should return 200 - anyone can read", http.MethodGet, mocks.MockMemberID, "", http.StatusOK},
+		{"should return 200 - owner updates", http.MethodPatch, mocks.MockAdminID, `{"rounds": 5}`, http.StatusOK},
+		{"should return 400 - owner turns it into an oversized superset", http.MethodPatch, mocks.MockAdminID, `{"kind": "superset", "members": [{"workout_id": 1}, {"workout_id": 2}, {"workout_id": 3}, {"workout_id": 4}, {"workout_id": 5}]}`, http.StatusBadRequest},
+		{"should return 403 - other user updates", http.MethodPatch, mocks.MockMemberID, `{"rounds": 5}`, http.StatusForbidden},
+		{"should return 204 - owner deletes", http.MethodDelete, mocks.MockAdminID, "", http.StatusNoContent},
+		{"should return 403 - other user deletes", http.MethodDelete, mocks.MockMemberID, "", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(tt.method, fmt.Sprintf("%s/%d", CircuitsUrl, mocks.MockCircuitID), bytes.NewBufferString(tt.payload))
+
+			res := execRequest(mux, authorize(t, app, req, tt.userID))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+		})
+	}
+
+	t.Run("should return 404 - missing circuit", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, CircuitsUrl+"/99", nil)
+
+		res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+		assertStatusCode(t, res.Code, http.StatusNotFound)
+	})
+}
+
+func TestGetCircuitTimeline(t *testing.T) {
+	app := newCircuitTestApplication(t)
+	mux := app.mount()
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/%d/timeline", CircuitsUrl, mocks.MockCircuitID), nil)
+
+	res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+	assertStatusCode(t, res.Code, http.StatusOK)
+
+	var body struct {
+		Data CircuitTimelineResponse `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+
+	// two rounds of 40s work, 20s rest, 40s work with a 60s break between
+	if body.Data.TotalSeconds != 2*(40+20+40)+60 {
+		t.Errorf("got total %d want %d", body.Data.TotalSeconds, 2*(40+20+40)+60)
+	}
+
+	wantPhases := []string{"work", "rest", "work", "round_rest", "work", "rest", "work"}
+	if len(body.Data.Phases) != len(wantPhases) {
+		t.Fatalf("got %d phases want %d", len(body.Data.Phases), len(wantPhases))
+	}
+
+	next := 0
+	for i, phase := range body.Data.Phases {
+		if phase.Phase != wantPhases[i] {
+			t.Errorf("phase %d: got %q want %q", i, phase.Phase, wantPhases[i])
+		}
+		if phase.StartSecond != next {
+			t.Errorf("phase %d: got start %d want %d", i, phase.StartSecond, next)
+		}
+		next += phase.DurationSeconds
+	}
+}
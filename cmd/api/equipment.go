@@ -2,10 +2,10 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/JerryLegend254/mfit_api/internal/store"
 	"github.com/go-chi/chi/v5"
@@ -19,6 +19,10 @@ type CreateEquipmentPayload struct {
 	Name string `json:"name" validate:"required,max=40"`
 }
 
+func (p *CreateEquipmentPayload) normalize() {
+	p.Name = strings.TrimSpace(p.Name)
+}
+
 // CreateEquipment godoc
 //
 //	@Summary		Creates a equipment
@@ -28,23 +32,19 @@ type CreateEquipmentPayload struct {
 //	@Produce		json
 //	@Param			payload	body		CreateEquipmentPayload	true	"Equipment payload"
 //	@Success		201		{object}	store.Equipment
-//	@Failure		400		{object}	error
-//	@Failure		403		{object}	error
-//	@Failure		500		{object}	error
+//	@Failure		400		{object}	Problem
+//	@Failure		409		{object}	Problem
+//	@Failure		422		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		500		{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/equipment [post]
 func (app *application) createEquipmentHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	var payload CreateEquipmentPayload
-
-	err := json.NewDecoder(r.Body).Decode(&payload)
+	payload, err := decodeJSON[CreateEquipmentPayload](w, r)
 	if err != nil {
-		app.badRequest(w, r, err)
-		return
-	}
-
-	if err := Validate.Struct(payload); err != nil {
-		app.badRequest(w, r, err)
+		app.decodeError(w, r, err)
 		return
 	}
 
@@ -53,12 +53,7 @@ func (app *application) createEquipmentHandler(w http.ResponseWriter, r *http.Re
 	}
 
 	if err = app.store.Equipment.Create(ctx, &equipment); err != nil {
-		switch err {
-		case store.ErrDuplicate:
-			app.conflictError(w, r, store.ErrDuplicateName)
-		default:
-			app.internalServerError(w, r, err)
-		}
+		app.storeError(w, r, err)
 		return
 
 	}
@@ -71,25 +66,41 @@ func (app *application) createEquipmentHandler(w http.ResponseWriter, r *http.Re
 // GetAllEquipments godoc
 //
 //	@Summary		Fetch all equipment
-//	@Description	Fetch all equipment
+//	@Description	Fetch a page of equipment
 //	@Tags			equipment
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	[]store.Equipment
-//	@Failure		403	{object}	error
-//	@Failure		500	{object}	error
+//	@Param			limit	query		int		false	"Page size (1-100)"	default(20)
+//	@Param			cursor	query		string	false	"Opaque cursor from a previous page"
+//	@Param			sort	query		string	false	"Sort field, prefix with - for descending"	Enums(id, -id, name, -name)
+//	@Success		200		{object}	[]store.Equipment
+//	@Failure		400		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		500		{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/equipment [get]
 func (app *application) fetchEquipmentsHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	equipment, err := app.store.Equipment.GetAll(ctx)
+	page, err := parsePaginatedQuery(r, store.EquipmentSorts)
+	if err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(page); err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	equipment, meta, err := app.store.Equipment.GetAll(ctx, page)
 	if err != nil {
 		app.internalServerError(w, r, err)
 		return
 	}
 
-	if err = app.jsonResponse(w, http.StatusOK, equipment); err != nil {
+	if err = app.paginatedJSONResponse(w, http.StatusOK, equipment, meta); err != nil {
 		app.internalServerError(w, r, err)
 	}
 }
@@ -103,9 +114,10 @@ func (app *application) fetchEquipmentsHandler(w http.ResponseWriter, r *http.Re
 //	@Produce		json
 //	@Param			equipmentId	path		int	true	"Equipment ID"
 //	@Success		200			{object}	store.Equipment
-//	@Failure		404			{object}	error
-//	@Failure		500			{object}	error
+//	@Failure		404			{object}	Problem
+//	@Failure		500			{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/equipment/{equipmentId} [get]
 func (app *application) getEquipmentHandler(w http.ResponseWriter, r *http.Request) {
 	equipment := getEquipmentFromContext(r)
@@ -122,13 +134,17 @@ func (app *application) getEquipmentHandler(w http.ResponseWriter, r *http.Reque
 //	@Tags			equipment
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path	int	true	"Equipment ID"
+//	@Param			id			path	int		true	"Equipment ID"
+//	@Param			X-TOTP-Code	header	string	true	"Current TOTP code or an unused recovery code"
 //	@Success		204
-//	@Failure		400	{object}	error
-//	@Failure		401	{object}	error
-//	@Failure		404	{object}	error
-//	@Failure		500	{object}	error
+//	@Failure		400	{object}	Problem
+//	@Failure		401	{object}	Problem
+//	@Failure		403	{object}	Problem
+//	@Failure		404	{object}	Problem
+//	@Failure		429	{object}	Problem
+//	@Failure		500	{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/equipment/{equipmentId} [delete]
 func (app *application) deleteEquipmentHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -151,6 +167,10 @@ type UpdateEquipmentPayload struct {
 	Name *string `json:"name" validate:"omitempty,max=40"`
 }
 
+func (p *UpdateEquipmentPayload) normalize() {
+	trimString(p.Name)
+}
+
 // UddateEquipment godoc
 //
 //	@Summary		Update a equipment
@@ -161,26 +181,23 @@ type UpdateEquipmentPayload struct {
 //	@Param			equipmentId	path		int						true	"Equipment ID"
 //	@Param			equipmentId	body		UpdateEquipmentPayload	true	"Equipment ID"
 //	@Success		200			{object}	store.Equipment
-//	@Failure		400			{object}	error
-//	@Failure		401			{object}	error
-//	@Failure		404			{object}	error
-//	@Failure		500			{object}	error
+//	@Failure		400			{object}	Problem
+//	@Failure		401			{object}	Problem
+//	@Failure		404			{object}	Problem
+//	@Failure		409			{object}	Problem
+//	@Failure		422			{object}	Problem
+//	@Failure		500			{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/equipment/{equipmentId} [patch]
 func (app *application) updateEquipmentHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	equipment := getEquipmentFromContext(r)
 
-	var payload UpdateEquipmentPayload
-
-	if err := readJSON(w, r, &payload); err != nil {
-		app.badRequest(w, r, err)
-		return
-	}
-
-	if err := Validate.Struct(payload); err != nil {
-		app.badRequest(w, r, err)
+	payload, err := decodeJSON[UpdateEquipmentPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
 		return
 	}
 
@@ -189,7 +206,7 @@ func (app *application) updateEquipmentHandler(w http.ResponseWriter, r *http.Re
 	}
 
 	if err := app.store.Equipment.Update(ctx, equipment); err != nil {
-		app.internalServerError(w, r, err)
+		app.storeError(w, r, err)
 		return
 	}
 
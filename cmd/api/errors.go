@@ -2,29 +2,98 @@ package main
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
 )
 
 var (
-	ErrBadRequest = errors.New("invalid payload check request body")
+	ErrInvalidCredentials = errors.New("invalid email or password")
 )
 
 func (app *application) internalServerError(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Errorw("internal server error", "error", err.Error(), "method", r.Method, "path", r.URL.Path)
-	writeJSONError(w, http.StatusInternalServerError, "something went wrong")
+	app.requestLogger(r).Errorw("internal server error", "error", err.Error(), "method", r.Method, "path", r.URL.Path)
+	writeProblem(w, r, newStatusProblem(http.StatusInternalServerError, "something went wrong"))
 }
 
 func (app *application) notFound(w http.ResponseWriter, r *http.Request) {
-	app.logger.Warnw("not found", "method", r.Method, "path", r.URL.Path)
-	writeJSONError(w, http.StatusNotFound, "not found")
+	app.requestLogger(r).Warnw("not found", "method", r.Method, "path", r.URL.Path)
+	writeProblem(w, r, newStatusProblem(http.StatusNotFound, ""))
 }
 
 func (app *application) conflictError(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Errorw("conflict error", "error", err.Error(), "method", r.Method, "path", r.URL.Path)
-	writeJSONError(w, http.StatusConflict, err.Error())
+	app.requestLogger(r).Errorw("conflict error", "error", err.Error(), "method", r.Method, "path", r.URL.Path)
+	p := newStatusProblem(http.StatusConflict, err.Error())
+	p.Type = problemTypeConflict
+	writeProblem(w, r, p)
 }
 
 func (app *application) badRequest(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Warnw("bad request", "error", err.Error(), "method", r.Method, "path", r.URL.Path)
-	writeJSONError(w, http.StatusBadRequest, ErrBadRequest.Error())
+	app.requestLogger(r).Warnw("bad request", "error", err.Error(), "method", r.Method, "path", r.URL.Path)
+	writeProblem(w, r, newProblem(http.StatusBadRequest, err))
+}
+
+// decodeError reports a payload that failed decodeJSON, bodies of the wrong
+// content type or size get their own status codes.
+func (app *application) decodeError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesErr *http.MaxBytesError
+
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, errUnsupportedMediaType):
+		status = http.StatusUnsupportedMediaType
+	case errors.As(err, &maxBytesErr):
+		status = http.StatusRequestEntityTooLarge
+	}
+
+	app.requestLogger(r).Warnw("invalid payload", "error", err.Error(), "method", r.Method, "path", r.URL.Path)
+	writeProblem(w, r, newProblem(status, err))
+}
+
+// storeError reports a write the database rejected, conflicts with existing
+// rows as 409 and values it would not store as 422, naming the field at
+// fault. Anything else is an internal error.
+func (app *application) storeError(w http.ResponseWriter, r *http.Request, err error) {
+	var constraintErr *store.ConstraintError
+
+	var status int
+	switch {
+	case errors.Is(err, store.ErrDuplicate), errors.Is(err, store.ErrSerialization):
+		status = http.StatusConflict
+	case errors.As(err, &constraintErr):
+		status = http.StatusUnprocessableEntity
+	default:
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	app.requestLogger(r).Warnw("rejected write", "error", err.Error(), "method", r.Method, "path", r.URL.Path)
+	writeProblem(w, r, newProblem(status, err))
+}
+
+func (app *application) invalidCredentials(w http.ResponseWriter, r *http.Request) {
+	app.requestLogger(r).Warnw("invalid credentials", "method", r.Method, "path", r.URL.Path)
+	writeProblem(w, r, newStatusProblem(http.StatusUnauthorized, ErrInvalidCredentials.Error()))
+}
+
+func (app *application) unauthorizedError(w http.ResponseWriter, r *http.Request, err error) {
+	app.requestLogger(r).Warnw("unauthorized error", "error", err.Error(), "method", r.Method, "path", r.URL.Path)
+	w.Header().Set("WWW-Authenticate", `Bearer realm="mfit"`)
+	writeProblem(w, r, newStatusProblem(http.StatusUnauthorized, ""))
+}
+
+func (app *application) forbiddenError(w http.ResponseWriter, r *http.Request, reason string) {
+	app.requestLogger(r).Warnw("forbidden", "reason", reason, "method", r.Method, "path", r.URL.Path)
+	writeProblem(w, r, newStatusProblem(http.StatusForbidden, reason))
+}
+
+// tooManyRequests turns the caller away until retryAfter has passed, telling
+// it when to come back in the Retry-After header.
+func (app *application) tooManyRequests(w http.ResponseWriter, r *http.Request, reason string, retryAfter time.Duration) {
+	app.requestLogger(r).Warnw("too many requests", "reason", reason, "method", r.Method, "path", r.URL.Path)
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	writeProblem(w, r, newStatusProblem(http.StatusTooManyRequests, reason))
 }
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"math/rand/v2"
+	"net/http"
+
+	"github.com/JerryLegend254/mfit_api/internal/generator"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+// maxSeed keeps seeds exact in JSON clients that use doubles, it matches the
+// seed's lte validation.
+const maxSeed = 1<<53 - 1
+
+// GenerateSessionPayload describes the session to build. An empty
+// equipment_ids allows any equipment and max_difficulty defaults to advanced.
+type GenerateSessionPayload struct {
+	BodyPartIDs       []int64 `json:"bodypart_ids" validate:"required,min=1,max=20,unique,dive,gt=0"`
+	EquipmentIDs      []int64 `json:"equipment_ids" validate:"max=50,unique,dive,gt=0"`
+	MaxDifficulty     string  `json:"max_difficulty" validate:"omitempty,oneof=beginner intermediate advanced"`
+	TimeBudgetMinutes int     `json:"time_budget_minutes" validate:"required,gte=5,lte=240"`
+	TargetIDs         []int64 `json:"target_ids" validate:"max=20,unique,dive,gt=0"`
+	Seed              *int64  `json:"seed" validate:"omitnil,gte=0,lte=9007199254740991"`
+}
+
+type GeneratedWorkout struct {
+	Position int `json:"position"`
+	store.PresentableWorkout
+}
+
+// GeneratedSessionResponse echoes the seed so the same session can be
+// generated again.
+type GeneratedSessionResponse struct {
+	Seed              int64              `json:"seed"`
+	TimeBudgetMinutes int                `json:"time_budget_minutes"`
+	TotalMinutes      int                `json:"total_minutes"`
+	Workouts          []GeneratedWorkout `json:"workouts"`
+	CoveredTargetIDs  []int64            `json:"covered_target_ids"`
+	MissingTargetIDs  []int64            `json:"missing_target_ids"`
+}
+
+// GenerateSession godoc
+//
+//	@Summary		Generates a session
+//	@Description	Selects and orders catalog workouts for the given body parts, equipment and difficulty ceiling within a time budget, covering the requested targets first with one workout per primary target. The same payload and seed always give the same session.
+//	@Tags			generate
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		GenerateSessionPayload	true	"Session constraints"
+//	@Success		200		{object}	GeneratedSessionResponse
+//	@Failure		400		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/generate/session [post]
+func (app *application) generateSessionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	payload, err := decodeJSON[GenerateSessionPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	if payload.MaxDifficulty == "" {
+		payload.MaxDifficulty = "advanced"
+	}
+
+	seed := rand.Int64N(maxSeed)
+	if payload.Seed != nil {
+		seed = *payload.Seed
+	}
+
+	filter := store.CandidateFilter{
+		BodyPartIDs:   payload.BodyPartIDs,
+		EquipmentIDs:  payload.EquipmentIDs,
+		MaxDifficulty: payload.MaxDifficulty,
+	}
+
+	workouts, err := app.store.Workouts.GetCandidates(ctx, filter)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	byID := make(map[int64]*store.PresentableWorkout, len(workouts))
+	candidates := make([]generator.Candidate, len(workouts))
+	for i, workout := range workouts {
+		byID[workout.ID] = &workouts[i]
+		candidates[i] = generator.Candidate{
+			WorkoutID:       workout.ID,
+			BodyPartID:      workout.BodyPartID,
+			PrimaryTargetID: workout.PrimaryTargetID,
+			Difficulty:      workout.Difficulty,
+			DurationMinutes: int(workout.DurationMinutes),
+		}
+	}
+
+	plan := generator.Generate(candidates, generator.Options{
+		TimeBudgetMinutes: payload.TimeBudgetMinutes,
+		TargetIDs:         payload.TargetIDs,
+		Seed:              uint64(seed),
+	})
+
+	res := GeneratedSessionResponse{
+		Seed:              seed,
+		TimeBudgetMinutes: payload.TimeBudgetMinutes,
+		TotalMinutes:      plan.TotalMinutes,
+		Workouts:          make([]GeneratedWorkout, len(plan.Workouts)),
+		CoveredTargetIDs:  plan.CoveredTargetIDs,
+		MissingTargetIDs:  plan.MissingTargetIDs,
+	}
+	for i, c := range plan.Workouts {
+		res.Workouts[i] = GeneratedWorkout{Position: i + 1, PresentableWorkout: *byID[c.WorkoutID]}
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, res); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
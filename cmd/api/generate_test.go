@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+var GenerateSessionUrl = newCollectionPath("generate/session")
+
+func TestGenerateSession(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		Workouts: new(mocks.MockWorkoutStore),
+	})
+	mux := app.mount()
+
+	generate := func(t *testing.T, payload string) (int, GeneratedSessionResponse) {
+		t.Helper()
+
+		req := newJSONRequest(http.MethodPost, GenerateSessionUrl, bytes.NewBufferString(payload))
+		res := execRequest(mux, req)
+
+		var body struct {
+			Data GeneratedSessionResponse `json:"data"`
+		}
+		if res.Code == http.StatusOK {
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return res.Code, body.Data
+	}
+
+	tests := []struct {
+		name               string
+		payload            string
+		expectedStatusCode int
+		expectedWorkouts   int
+		expectedMissing    int
+	}{
+		// two body parts with two primary targets each, ten minutes a workout
+		{"should return 200 - fills the budget", `{"bodypart_ids": [1, 2], "time_budget_minutes": 45, "seed": 7}`, http.StatusOK, 4, 0},
+		{"should return 200 - stops at the budget", `{"bodypart_ids": [1, 2], "time_budget_minutes": 25, "seed": 7}`, http.StatusOK, 2, 0},
+		{"should return 200 - difficulty ceiling", `{"bodypart_ids": [1], "max_difficulty": "beginner", "time_budget_minutes": 60, "seed": 7}`, http.StatusOK, 1, 0},
+		{"should return 200 - equipment filter", `{"bodypart_ids": [1], "equipment_ids": [2], "time_budget_minutes": 60, "seed": 7}`, http.StatusOK, 2, 0},
+		{"should return 200 - uncoverable target", `{"bodypart_ids": [1], "target_ids": [11, 99], "time_budget_minutes": 60, "seed": 7}`, http.StatusOK, 2, 1},
+		{"should return 400 - no body parts", `{"bodypart_ids": [], "time_budget_minutes": 45}`, http.StatusBadRequest, 0, 0},
+		{"should return 400 - budget too small", `{"bodypart_ids": [1], "time_budget_minutes": 2}`, http.StatusBadRequest, 0, 0},
+		{"should return 400 - unknown difficulty", `{"bodypart_ids": [1], "max_difficulty": "elite", "time_budget_minutes": 45}`, http.StatusBadRequest, 0, 0},
+		{"should return 400 - negative seed", `{"bodypart_ids": [1], "time_budget_minutes": 45, "seed": -1}`, http.StatusBadRequest, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, res := generate(t, tt.payload)
+
+			assertStatusCode(t, code, tt.expectedStatusCode)
+
+			if tt.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			if len(res.Workouts) != tt.expectedWorkouts {
+				t.Errorf("got %d workouts want %d", len(res.Workouts), tt.expectedWorkouts)
+			}
+
+			if len(res.MissingTargetIDs) != tt.expectedMissing {
+				t.Errorf("got missing %v want %d", res.MissingTargetIDs, tt.expectedMissing)
+			}
+
+			if res.TotalMinutes > res.TimeBudgetMinutes {
+				t.Errorf("got %d minutes over a %d minute budget", res.TotalMinutes, res.TimeBudgetMinutes)
+			}
+
+			seen := make(map[int64]bool)
+			for i, w := range res.Workouts {
+				if w.Position != i+1 {
+					t.Errorf("workout %d: got position %d", i, w.Position)
+				}
+				if seen[w.PrimaryTargetID] {
+					t.Errorf("primary target %d covered twice", w.PrimaryTargetID)
+				}
+				seen[w.PrimaryTargetID] = true
+			}
+		})
+	}
+
+	t.Run("should return the same session for the echoed seed", func(t *testing.T) {
+		_, first := generate(t, `{"bodypart_ids": [1, 2, 3], "time_budget_minutes": 40}`)
+
+		payload, _ := json.Marshal(map[string]any{"bodypart_ids": []int{1, 2, 3}, "time_budget_minutes": 40, "seed": first.Seed})
+		_, second := generate(t, string(payload))
+
+		if len(first.Workouts) != len(second.Workouts) {
+			t.Fatalf("got %d then %d workouts", len(first.Workouts), len(second.Workouts))
+		}
+		for i := range first.Workouts {
+			if first.Workouts[i].ID != second.Workouts[i].ID {
+				t.Errorf("position %d: got workout %d then %d", i+1, first.Workouts[i].ID, second.Workouts[i].ID)
+			}
+		}
+	})
+}
@@ -2,8 +2,14 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
+	"mime"
 	"net/http"
+	"reflect"
+	"strings"
 
+	"github.com/JerryLegend254/mfit_api/internal/store"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -13,6 +19,19 @@ var Validate *validator.Validate
 
 func init() {
 	Validate = validator.New(validator.WithRequiredStructEnabled())
+
+	// report fields by their JSON names so clients can match them to the
+	// payload they sent
+	Validate.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return f.Name
+		}
+		return name
+	})
 }
 
 func writeJSON(w http.ResponseWriter, status int, data interface{}) error {
@@ -22,26 +41,76 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) error {
 	return json.NewEncoder(w).Encode(data)
 }
 
+// maxBodyBytes caps the size of request bodies.
+const maxBodyBytes = 1 << 20 // 1 MB
+
+var (
+	errUnsupportedMediaType = errors.New("content type must be application/json")
+	errTrailingData         = errors.New("body must only contain a single JSON value")
+)
+
+// normalizer is implemented by payloads that clean up their input, such as
+// trimming names, before they are validated.
+type normalizer interface {
+	normalize()
+}
+
+// readJSON strictly decodes a single JSON value from the request body into
+// data. Bodies sent without a JSON content type, larger than maxBodyBytes,
+// with unknown fields or with data after the value are rejected.
 func readJSON(w http.ResponseWriter, r *http.Request, data interface{}) error {
-	maxBytes := int64(1 << 20) // 1 MB
-	r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != jsonContentType {
+		return errUnsupportedMediaType
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
 
 	dec := json.NewDecoder(r.Body)
 	dec.DisallowUnknownFields()
 
-	return dec.Decode(data)
+	if err := dec.Decode(data); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		return errTrailingData
+	}
+
+	return nil
 }
 
-func writeJSONError(w http.ResponseWriter, status int, message string) error {
-	type errorResponse struct {
-		Error string `json:"error"`
+// decodeJSON reads a payload of type T from the request body, normalizes
+// it and validates it.
+func decodeJSON[T any](w http.ResponseWriter, r *http.Request) (T, error) {
+	var payload T
+
+	if err := readJSON(w, r, &payload); err != nil {
+		return payload, err
+	}
+
+	if n, ok := any(&payload).(normalizer); ok {
+		n.normalize()
 	}
 
-	data := &errorResponse{
-		Error: message,
+	if err := Validate.Struct(payload); err != nil {
+		return payload, err
+	}
+
+	return payload, nil
+}
+
+// trimString and trimStrings normalize optional text fields in place.
+func trimString(s *string) {
+	if s != nil {
+		*s = strings.TrimSpace(*s)
 	}
+}
 
-	return writeJSON(w, status, data)
+func trimStrings(s []string) {
+	for i := range s {
+		s[i] = strings.TrimSpace(s[i])
+	}
 }
 
 func (app *application) jsonResponse(w http.ResponseWriter, status int, data interface{}) error {
@@ -50,3 +119,11 @@ func (app *application) jsonResponse(w http.ResponseWriter, status int, data int
 	}
 	return writeJSON(w, status, &jsonResponse{Data: data})
 }
+
+func (app *application) paginatedJSONResponse(w http.ResponseWriter, status int, data interface{}, meta store.PageMeta) error {
+	type paginatedJSONResponse struct {
+		Data interface{}    `json:"data"`
+		Meta store.PageMeta `json:"meta"`
+	}
+	return writeJSON(w, status, &paginatedJSONResponse{Data: data, Meta: meta})
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		BodyParts:         new(mocks.MockBodyPartStore),
+		Workouts:          new(mocks.MockWorkoutStore),
+		Users:             new(mocks.MockUserStore),
+		APIKeys:           new(mocks.MockAPIKeyStore),
+		TOTP:              new(mocks.MockTOTPStore),
+		Profiles:          new(mocks.MockProfileStore),
+		Routines:          new(mocks.MockRoutineStore),
+		Circuits:          new(mocks.MockCircuitStore),
+		Sessions:          new(mocks.MockSessionStore),
+		StrengthStandards: new(mocks.MockStrengthStandardStore),
+	})
+	mux := app.mount()
+
+	endpoints := []struct {
+		method string
+		path   string
+		// userID signs the request in, zero leaves it anonymous
+		userID int64
+	}{
+		{http.MethodPost, "auth/register", 0},
+		{http.MethodPost, "auth/login", 0},
+		{http.MethodPost, "auth/activate", 0},
+		{http.MethodPost, "auth/password/forgot", 0},
+		{http.MethodPost, "auth/password/reset", 0},
+		{http.MethodPost, "auth/totp/confirm", mocks.MockAdminID},
+		{http.MethodPost, "analysis/muscle-load", 0},
+		{http.MethodPost, "generate/session", 0},
+		{http.MethodPost, "bodyparts", mocks.MockAdminID},
+		{http.MethodPatch, "bodyparts/1", mocks.MockAdminID},
+		{http.MethodPost, "targets", mocks.MockAdminID},
+		{http.MethodPost, "equipment", mocks.MockAdminID},
+		{http.MethodPost, "workouts", mocks.MockAdminID},
+		{http.MethodPatch, "workouts/1", mocks.MockAdminID},
+		{http.MethodPost, "workouts/1/strength-estimate", 0},
+		{http.MethodPut, "workouts/1/strength-standards", mocks.MockAdminID},
+		{http.MethodPatch, "me/profile", mocks.MockAdminID},
+		{http.MethodPost, "routines", mocks.MockAdminID},
+		{http.MethodPatch, fmt.Sprintf("routines/%d", mocks.MockAdminRoutineID), mocks.MockAdminID},
+		{http.MethodPost, "sessions", mocks.MockAdminID},
+		{http.MethodPost, fmt.Sprintf("sessions/%d/sets", mocks.MockAdminSessionID), mocks.MockAdminID},
+		{http.MethodPost, "circuits", mocks.MockAdminID},
+		{http.MethodPatch, fmt.Sprintf("circuits/%d", mocks.MockCircuitID), mocks.MockAdminID},
+		{http.MethodPost, "api-keys", mocks.MockAdminID},
+		{http.MethodPatch, "api-keys/1", mocks.MockAdminID},
+		{http.MethodPut, fmt.Sprintf("users/%d/role", mocks.MockMemberID), mocks.MockAdminID},
+		{http.MethodPut, "admin/log-level", mocks.MockAdminID},
+	}
+
+	tests := []struct {
+		name               string
+		contentType        string
+		body               string
+		expectedStatusCode int
+		expectedType       string
+	}{
+		{"should return 415 - form content type", "application/x-www-form-urlencoded", "name=chest", http.StatusUnsupportedMediaType, problemTypeBlank},
+		{"should return 415 - malformed content type", "application/json; charset", `{}`, http.StatusUnsupportedMediaType, problemTypeBlank},
+		{"should return 415 - missing content type", "", `{}`, http.StatusUnsupportedMediaType, problemTypeBlank},
+		{"should return 413 - oversized body", jsonContentType, `{"name": "` + strings.Repeat("a", maxBodyBytes) + `"}`, http.StatusRequestEntityTooLarge, problemTypeMalformedBody},
+		{"should return 400 - unknown field", jsonContentType, `{"unexpected": true}`, http.StatusBadRequest, problemTypeValidation},
+		{"should return 400 - trailing data", jsonContentType, `{} {}`, http.StatusBadRequest, problemTypeMalformedBody},
+		{"should return 400 - trailing garbage", "application/json; charset=utf-8", `{}]`, http.StatusBadRequest, problemTypeMalformedBody},
+		{"should return 400 - empty body", jsonContentType, "", http.StatusBadRequest, problemTypeMalformedBody},
+	}
+	for _, e := range endpoints {
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s %s %s", e.method, e.path, tt.name), func(t *testing.T) {
+				req, _ := http.NewRequest(e.method, newCollectionPath(e.path), strings.NewReader(tt.body))
+				if tt.contentType != "" {
+					req.Header.Set("Content-Type", tt.contentType)
+				}
+				if e.userID != 0 {
+					req = authorize(t, app, req, e.userID)
+				}
+
+				res := execRequest(mux, req)
+
+				assertStatusCode(t, res.Code, tt.expectedStatusCode)
+				assertContentType(t, res.Header().Get("Content-Type"), problemContentType)
+
+				var problem Problem
+				if err := json.NewDecoder(res.Body).Decode(&problem); err != nil {
+					t.Fatal(err)
+				}
+
+				if problem.Type != tt.expectedType {
+					t.Errorf("got type %q want %q", problem.Type, tt.expectedType)
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeJSONNormalizes(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		BodyParts: new(mocks.MockBodyPartStore),
+		Users:     new(mocks.MockUserStore),
+	})
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		payload            string
+		expectedStatusCode int
+		expectedName       string
+	}{
+		{"should return 201 - trimmed name", `{"name": "  Chest ", "image_url": " chest.png"}`, http.StatusCreated, "Chest"},
+		{"should return 400 - blank name", `{"name": "   ", "image_url": "chest.png"}`, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newPostBodyPartRequest([]byte(tt.payload))
+
+			res := execRequest(mux, authorize(t, app, req, mocks.MockAdminID))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			if tt.expectedStatusCode != http.StatusCreated {
+				return
+			}
+
+			var body struct {
+				Data store.BodyPart `json:"data"`
+			}
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			if body.Data.Name != tt.expectedName {
+				t.Errorf("got name %q want %q", body.Data.Name, tt.expectedName)
+			}
+
+			if body.Data.ImageUrl != "chest.png" {
+				t.Errorf("got image url %q want %q", body.Data.ImageUrl, "chest.png")
+			}
+		})
+	}
+}
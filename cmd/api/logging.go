@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync/atomic"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// requestIDPattern limits the IDs accepted from callers to ones that are safe
+// to echo back and write into logs.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type requestIDContextKey string
+
+var requestIDCtxKey requestIDContextKey = "requestID"
+
+type accessLogContextKey string
+
+var accessLogCtxKey accessLogContextKey = "accessLog"
+
+// accessLogEntry collects what inner middleware learns about a request, such
+// as who made it, for the access log written once the response is done.
+type accessLogEntry struct {
+	principal string
+}
+
+// requestIDMiddleware keeps the X-Request-ID of the caller, or assigns a new
+// one, and echoes it on the response.
+func (app *application) requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id = newRequestID()
+		}
+
+		w.Header().Set(requestIDHeader, id)
+
+		ctx := context.WithValue(r.Context(), requestIDCtxKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requestIDSeq keeps fallback request IDs unique when the random source
+// fails.
+var requestIDSeq atomic.Uint64
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%016x%016x", time.Now().UnixNano(), requestIDSeq.Add(1))
+	}
+	return hex.EncodeToString(b)
+}
+
+func getRequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey).(string)
+	return id
+}
+
+// accessLogMiddleware attaches a logger tagged with the request ID for
+// handlers and stores, and logs every request once it is served.
+func (app *application) accessLogMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		reqLogger := app.logger.With("request_id", getRequestIDFromContext(r.Context()))
+		entry := &accessLogEntry{}
+
+		ctx := logger.WithContext(r.Context(), reqLogger)
+		ctx = context.WithValue(ctx, accessLogCtxKey, entry)
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		fields := []interface{}{
+			"method", r.Method,
+			"route", routePattern(r),
+			"path", r.URL.Path,
+			"status", status,
+			"bytes", ww.BytesWritten(),
+			"latency", time.Since(start),
+			"remote_addr", r.RemoteAddr,
+		}
+		if entry.principal != "" {
+			fields = append(fields, "principal", entry.principal)
+		}
+
+		if status >= http.StatusInternalServerError {
+			reqLogger.Errorw("request", fields...)
+		} else {
+			reqLogger.Infow("request", fields...)
+		}
+	})
+}
+
+// routePattern is the route chi matched, unmatched requests fall back to the
+// raw path.
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return r.URL.Path
+}
+
+// recordPrincipal notes the caller of ctx for the access log.
+func recordPrincipal(ctx context.Context, p *principal) {
+	if entry, ok := ctx.Value(accessLogCtxKey).(*accessLogEntry); ok {
+		entry.principal = p.ID
+	}
+}
+
+// requestLogger is the logger of the request, or the application logger
+// outside of accessLogMiddleware.
+func (app *application) requestLogger(r *http.Request) logger.Logger {
+	if l, ok := logger.FromContext(r.Context()); ok {
+		return l
+	}
+	return app.logger
+}
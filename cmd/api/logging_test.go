@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/logger"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+func TestRequestID(t *testing.T) {
+	app := newTestApplication(t, store.Storage{BodyParts: new(mocks.MockBodyPartStore)})
+	mux := app.mount()
+
+	tests := []struct {
+		name       string
+		header     string
+		expectedID string
+	}{
+		{"should keep the caller request id", "client-42", "client-42"},
+		{"should replace an unsafe request id", "bad id\n", ""},
+		{"should replace an oversized request id", strings.Repeat("a", 129), ""},
+		{"should assign a missing request id", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newGetBodyPartsRequest()
+			if tt.header != "" {
+				req.Header.Set(requestIDHeader, tt.header)
+			}
+
+			res := execRequest(mux, req)
+
+			assertStatusCode(t, res.Code, http.StatusOK)
+
+			id := res.Header().Get(requestIDHeader)
+			if tt.expectedID != "" && id != tt.expectedID {
+				t.Errorf("got request id %q want %q", id, tt.expectedID)
+			}
+
+			if tt.expectedID == "" && (len(id) != 32 || id == tt.header) {
+				t.Errorf("got request id %q want a generated one", id)
+			}
+		})
+	}
+
+	t.Run("should return the request id of internal errors", func(t *testing.T) {
+		handler := app.requestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			app.internalServerError(w, r, errors.New("connection refused"))
+		}))
+
+		req, _ := http.NewRequest(http.MethodGet, BodyPartUrl, nil)
+		req.Header.Set(requestIDHeader, "test-request")
+
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+
+		assertStatusCode(t, res.Code, http.StatusInternalServerError)
+
+		var body Problem
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		if body.RequestID != "test-request" {
+			t.Errorf("got request id %q want %q", body.RequestID, "test-request")
+		}
+	})
+}
+
+func TestAccessLog(t *testing.T) {
+	app := newRoutineTestApplication(t)
+	testLogger, logs := logger.NewTestLogger()
+	app.logger = testLogger
+	mux := app.mount()
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/%d", RoutinesUrl, mocks.MockMemberRoutineID), nil)
+	req = authorize(t, app, req, mocks.MockMemberID)
+	req.Header.Set(requestIDHeader, "test-request")
+
+	res := execRequest(mux, req)
+
+	assertStatusCode(t, res.Code, http.StatusOK)
+
+	entries := logs.FilterMessage("request").All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d access log entries want 1", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	expected := map[string]interface{}{
+		"request_id": "test-request",
+		"method":     http.MethodGet,
+		"route":      "/api/v1/routines/{routineId}",
+		"status":     int64(http.StatusOK),
+		"bytes":      int64(res.Body.Len()),
+		"principal":  "user:2",
+	}
+	for key, want := range expected {
+		if fields[key] != want {
+			t.Errorf("got %s %v want %v", key, fields[key], want)
+		}
+	}
+
+	if _, ok := fields["latency"]; !ok {
+		t.Error("expected the latency to be logged")
+	}
+}
+
+func TestRequestLoggerInHandlers(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		Workouts: new(mocks.MockWorkoutStore),
+		Users:    new(mocks.MockUserStore),
+		TOTP:     new(mocks.MockTOTPStore),
+	})
+	testLogger, logs := logger.NewTestLogger()
+	app.logger = testLogger
+	mux := app.mount()
+
+	req := newDeleteWorkoutRequest(1)
+	req.Header.Set(totpHeader, mocks.MockRecoveryCode)
+	req.Header.Set(requestIDHeader, "test-request")
+
+	res := execRequest(mux, authorize(t, app, req, mocks.MockAdminID))
+
+	assertStatusCode(t, res.Code, http.StatusNoContent)
+
+	entries := logs.FilterMessage("recovery code used for step-up").All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d recovery code log entries want 1", len(entries))
+	}
+
+	if got := entries[0].ContextMap()["request_id"]; got != "test-request" {
+		t.Errorf("got request id %v want %q", got, "test-request")
+	}
+}
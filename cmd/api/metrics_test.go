@@ -0,0 +1,97 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/metrics"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		BodyParts: new(mocks.MockBodyPartStore),
+		Workouts:  new(mocks.MockWorkoutStore),
+		Users:     new(mocks.MockUserStore),
+	})
+	mux := app.mount()
+
+	t.Run("should count requests by route pattern", func(t *testing.T) {
+		ok := metrics.HTTPRequests.WithLabelValues(http.MethodGet, "/api/v1/bodyparts/{bodyPartId}", "200")
+		notFound := metrics.HTTPRequests.WithLabelValues(http.MethodGet, unmatchedRoute, "404")
+		before, beforeNotFound := testutil.ToFloat64(ok), testutil.ToFloat64(notFound)
+
+		execRequest(mux, newGetBodyPartRequest(1))
+		execRequest(mux, newGetBodyPartRequest(2))
+		req, _ := http.NewRequest(http.MethodGet, "/no/such/route", nil)
+		execRequest(mux, req)
+
+		if got := testutil.ToFloat64(ok) - before; got != 2 {
+			t.Errorf("got %v matched requests want 2", got)
+		}
+
+		if got := testutil.ToFloat64(notFound) - beforeNotFound; got != 1 {
+			t.Errorf("got %v unmatched requests want 1", got)
+		}
+
+		other := metrics.HTTPRequests.WithLabelValues(otherMethod, unmatchedRoute, "405")
+		beforeOther := testutil.ToFloat64(other)
+
+		req, _ = http.NewRequest("BREW", newCollectionPath("bodyparts/1"), nil)
+		execRequest(mux, req)
+
+		if got := testutil.ToFloat64(other) - beforeOther; got != 1 {
+			t.Errorf("got %v requests with other methods want 1", got)
+		}
+
+		if got := testutil.ToFloat64(metrics.HTTPInFlight); got != 0 {
+			t.Errorf("got %v requests in flight want 0", got)
+		}
+	})
+
+	t.Run("should count created workouts", func(t *testing.T) {
+		before := testutil.ToFloat64(metrics.WorkoutsCreated)
+
+		payload := `{"name": "Squat", "bodypart_id": 1, "equipment_id": 1, "difficulty": "beginner", "primary_target": 1, "secondary_targets": []}`
+		req := newJSONRequest(http.MethodPost, newCollectionPath("workouts"), strings.NewReader(payload))
+		res := execRequest(mux, authorize(t, app, req, mocks.MockAdminID))
+
+		assertStatusCode(t, res.Code, http.StatusCreated)
+
+		if got := testutil.ToFloat64(metrics.WorkoutsCreated) - before; got != 1 {
+			t.Errorf("got %v workouts created want 1", got)
+		}
+	})
+
+	t.Run("should return 401 - anonymous scrape", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, newCollectionPath("metrics"), nil)
+		res := execRequest(mux, req)
+
+		assertStatusCode(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("should return 403 - member scrape", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, newCollectionPath("metrics"), nil)
+		res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+		assertStatusCode(t, res.Code, http.StatusForbidden)
+	})
+
+	t.Run("should expose the metrics", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, newCollectionPath("metrics"), nil)
+		res := execRequest(mux, authorize(t, app, req, mocks.MockAdminID))
+
+		assertStatusCode(t, res.Code, http.StatusOK)
+
+		body, _ := io.ReadAll(res.Body)
+		for _, name := range []string{"mfit_http_requests_total", "mfit_http_request_duration_seconds", "mfit_workouts_created_total"} {
+			if !strings.Contains(string(body), name) {
+				t.Errorf("expected %s in the exposition", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/auth"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+type userContextKey string
+
+var userCtxKey userContextKey = "user"
+
+// APIKeyMiddleware authenticates callers presenting an X-API-Key header.
+// Requests without the header continue unauthenticated.
+func (app *application) APIKeyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		key := r.Header.Get("X-API-Key")
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		apiKey, err := app.store.APIKeys.GetByHash(ctx, auth.HashAPIKey(key))
+		if err != nil {
+			switch err {
+			case store.ErrNotFound:
+				app.unauthorizedError(w, r, errors.New("unknown api key"))
+			default:
+				app.internalServerError(w, r, err)
+			}
+			return
+		}
+
+		if !apiKey.Active(time.Now()) {
+			app.unauthorizedError(w, r, fmt.Errorf("api key %s is expired or revoked", apiKey.Prefix))
+			return
+		}
+
+		if err := app.store.APIKeys.Touch(ctx, apiKey.ID); err != nil {
+			app.requestLogger(r).Warnw("failed to record api key usage", "key", apiKey.Prefix, "error", err.Error())
+		}
+
+		scopes := make([]permission, len(apiKey.Scopes))
+		for i, scope := range apiKey.Scopes {
+			scopes[i] = permission(scope)
+		}
+
+		p := &principal{ID: "key:" + apiKey.Prefix, Scopes: scopes}
+		next.ServeHTTP(w, r.WithContext(withPrincipal(ctx, p)))
+	})
+}
+
+// AuthTokenMiddleware requires a bearer credential unless an earlier
+// middleware already authenticated the request with an API key.
+func (app *application) AuthTokenMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if getPrincipalFromContext(r) != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			app.unauthorizedError(w, r, errors.New("authorization header is missing"))
+			return
+		}
+
+		scheme, token, ok := strings.Cut(authHeader, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			app.unauthorizedError(w, r, errors.New("authorization header is malformed"))
+			return
+		}
+
+		if static, ok := auth.LookupStaticToken(app.config.auth.staticTokens, token); ok {
+			p := &principal{ID: "token:" + static.Name, Role: static.Role}
+			next.ServeHTTP(w, r.WithContext(withPrincipal(ctx, p)))
+			return
+		}
+
+		subject, err := app.authenticator.ValidateToken(token)
+		if err != nil {
+			app.unauthorizedError(w, r, err)
+			return
+		}
+
+		userID, err := strconv.ParseInt(subject, 10, 64)
+		if err != nil {
+			app.unauthorizedError(w, r, err)
+			return
+		}
+
+		user, err := app.store.Users.GetByID(ctx, userID)
+		if err != nil {
+			switch err {
+			case store.ErrNotFound:
+				app.unauthorizedError(w, r, err)
+			default:
+				app.internalServerError(w, r, err)
+			}
+			return
+		}
+
+		p := &principal{ID: "user:" + subject, Role: user.Role, User: user}
+		next.ServeHTTP(w, r.WithContext(withPrincipal(ctx, p)))
+	})
+}
+
+func getUserFromContext(r *http.Request) *store.User {
+	user, _ := r.Context().Value(userCtxKey).(*store.User)
+	return user
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/auth"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+func TestAuthTokenMiddleware(t *testing.T) {
+	store := store.Storage{
+		Workouts: new(mocks.MockWorkoutStore),
+		Users:    new(mocks.MockUserStore),
+		TOTP:     new(mocks.MockTOTPStore),
+	}
+
+	app := newTestApplication(t, store)
+	mux := app.mount()
+
+	expired, _, _ := auth.NewJWTAuthenticator("test-secret", "mfit-test", -time.Minute).GenerateToken("1")
+	foreign, _, _ := auth.NewJWTAuthenticator("other-secret", "mfit-test", time.Hour).GenerateToken("1")
+
+	tests := []struct {
+		name               string
+		authorization      string
+		expectedStatusCode int
+	}{
+		{"should return 401 - missing header", "", http.StatusUnauthorized},
+		{"should return 401 - wrong scheme", "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		{"should return 401 - empty token", "Bearer ", http.StatusUnauthorized},
+		{"should return 401 - expired token", "Bearer " + expired, http.StatusUnauthorized},
+		{"should return 401 - foreign signature", "Bearer " + foreign, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newDeleteWorkoutRequest(1)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			res := execRequest(mux, req)
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			if res.Header().Get("WWW-Authenticate") == "" {
+				t.Error("expected a WWW-Authenticate header")
+			}
+		})
+	}
+
+	t.Run("should return 401 - unknown user", func(t *testing.T) {
+		res := execRequest(mux, authorize(t, app, newDeleteWorkoutRequest(1), 99))
+
+		assertStatusCode(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("should return 204 - valid token", func(t *testing.T) {
+		res := execRequest(mux, authorize(t, app, stepUp(t, newDeleteWorkoutRequest(1)), mocks.MockAdminID))
+
+		assertStatusCode(t, res.Code, http.StatusNoContent)
+	})
+
+	t.Run("should return 200 - public route", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, newCollectionPath("ping"), nil)
+
+		res := execRequest(mux, req)
+
+		assertStatusCode(t, res.Code, http.StatusOK)
+	})
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+type permission string
+
+const (
+	permCatalogRead   permission = store.ScopeCatalogRead
+	permCatalogWrite  permission = store.ScopeCatalogWrite
+	permMetricsRead   permission = store.ScopeMetricsRead
+	permUsersManage   permission = "users:manage"
+	permAPIKeysManage permission = "apikeys:manage"
+	permLogsManage    permission = "logs:manage"
+)
+
+// rolePermissions lists what each role may do. Roles missing from the map
+// are granted nothing.
+var rolePermissions = map[string][]permission{
+	store.RoleAdmin:  {permCatalogRead, permCatalogWrite, permUsersManage, permAPIKeysManage, permLogsManage, permMetricsRead},
+	store.RoleCoach:  {permCatalogRead},
+	store.RoleMember: {permCatalogRead},
+}
+
+func isKnownRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+type principalContextKey string
+
+var principalCtxKey principalContextKey = "principal"
+
+// principal is the caller a request is authenticated as. User is nil when
+// the caller presented a static token or an API key. API keys carry their
+// own scopes instead of a role.
+type principal struct {
+	ID     string
+	Role   string
+	Scopes []permission
+	User   *store.User
+}
+
+func (p *principal) can(perm permission) bool {
+	if p.Scopes != nil {
+		return slices.Contains(p.Scopes, perm)
+	}
+	return slices.Contains(rolePermissions[p.Role], perm)
+}
+
+func (p *principal) denialReason(perm permission) string {
+	if p.Scopes != nil {
+		return fmt.Sprintf("api key is not scoped for %s", perm)
+	}
+	return fmt.Sprintf("role %q does not grant %s", p.Role, perm)
+}
+
+// requirePermission rejects requests whose principal lacks perm. It must run
+// after AuthTokenMiddleware.
+func (app *application) requirePermission(perm permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p := getPrincipalFromContext(r)
+			if p == nil {
+				app.unauthorizedError(w, r, fmt.Errorf("no principal for %s", perm))
+				return
+			}
+
+			if !p.can(perm) {
+				app.forbiddenError(w, r, p.denialReason(perm))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requirePermissionIfAuthenticated lets anonymous requests through but holds
+// authenticated callers, such as read-only API keys, to perm.
+func (app *application) requirePermissionIfAuthenticated(perm permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if p := getPrincipalFromContext(r); p != nil && !p.can(perm) {
+				app.forbiddenError(w, r, p.denialReason(perm))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requireUser rejects principals without a user account, such as API keys,
+// on routes that act on the caller's own data. It must run after
+// AuthTokenMiddleware.
+func (app *application) requireUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if getUserFromContext(r) == nil {
+			app.forbiddenError(w, r, "this endpoint requires a user account")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func withPrincipal(ctx context.Context, p *principal) context.Context {
+	recordPrincipal(ctx, p)
+	ctx = context.WithValue(ctx, principalCtxKey, p)
+	if p.User != nil {
+		ctx = context.WithValue(ctx, userCtxKey, p.User)
+	}
+	return ctx
+}
+
+func getPrincipalFromContext(r *http.Request) *principal {
+	p, _ := r.Context().Value(principalCtxKey).(*principal)
+	return p
+}
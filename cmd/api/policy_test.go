@@ -0,0 +1,115 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/auth"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+func TestCatalogPolicy(t *testing.T) {
+	store := store.Storage{
+		BodyParts: new(mocks.MockBodyPartStore),
+		Workouts:  new(mocks.MockWorkoutStore),
+		Users:     new(mocks.MockUserStore),
+	}
+
+	app := newTestApplication(t, store)
+	app.config.auth.staticTokens = []auth.StaticToken{
+		{Name: "kiosk", Token: "kiosk-token", Role: "member"},
+		{Name: "pipeline", Token: "pipeline-token", Role: "admin"},
+	}
+	mux := app.mount()
+
+	t.Run("should return 200 - anonymous read", func(t *testing.T) {
+		res := execRequest(mux, newGetBodyPartsRequest())
+
+		assertStatusCode(t, res.Code, http.StatusOK)
+	})
+
+	t.Run("should return 403 - member workout delete", func(t *testing.T) {
+		res := execRequest(mux, authorize(t, app, newDeleteWorkoutRequest(1), mocks.MockMemberID))
+
+		assertStatusCode(t, res.Code, http.StatusForbidden)
+
+		if !strings.Contains(res.Body.String(), "catalog:write") {
+			t.Errorf("expected the denial reason in %q", res.Body.String())
+		}
+	})
+
+	t.Run("should return 403 - member workout update", func(t *testing.T) {
+		req := newPatchWorkoutRequest(1, []byte(`{"name": "push up"}`))
+
+		res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+		assertStatusCode(t, res.Code, http.StatusForbidden)
+	})
+
+	t.Run("should return 201 - admin create", func(t *testing.T) {
+		req := newPostBodyPartRequest([]byte(`{"name": "chest", "image_url": "https://example.com/chest.png"}`))
+
+		res := execRequest(mux, authorize(t, app, req, mocks.MockAdminID))
+
+		assertStatusCode(t, res.Code, http.StatusCreated)
+	})
+
+	t.Run("should return 201 - admin static token", func(t *testing.T) {
+		req := newPostBodyPartRequest([]byte(`{"name": "chest", "image_url": "https://example.com/chest.png"}`))
+		req.Header.Set("Authorization", "Bearer pipeline-token")
+
+		res := execRequest(mux, req)
+
+		assertStatusCode(t, res.Code, http.StatusCreated)
+	})
+
+	t.Run("should return 403 - admin static token delete without a user for totp", func(t *testing.T) {
+		req := stepUp(t, newDeleteWorkoutRequest(1))
+		req.Header.Set("Authorization", "Bearer pipeline-token")
+
+		res := execRequest(mux, req)
+
+		assertStatusCode(t, res.Code, http.StatusForbidden)
+	})
+
+	t.Run("should return 403 - member static token", func(t *testing.T) {
+		req := newDeleteWorkoutRequest(1)
+		req.Header.Set("Authorization", "Bearer kiosk-token")
+
+		res := execRequest(mux, req)
+
+		assertStatusCode(t, res.Code, http.StatusForbidden)
+	})
+}
+
+func TestUpdateUserRole(t *testing.T) {
+	app := newTestApplication(t, store.Storage{Users: new(mocks.MockUserStore)})
+	mux := app.mount()
+
+	newRequest := func(id string, payload string) *http.Request {
+		req := newJSONRequest(http.MethodPut, newCollectionPath("users/"+id+"/role"), strings.NewReader(payload))
+		return req
+	}
+
+	tests := []struct {
+		name               string
+		userID             int64
+		target             string
+		payload            string
+		expectedStatusCode int
+	}{
+		{"should return 200 - admin promotes member", mocks.MockAdminID, "2", `{"role": "coach"}`, http.StatusOK},
+		{"should return 400 - unknown role", mocks.MockAdminID, "2", `{"role": "owner"}`, http.StatusBadRequest},
+		{"should return 404 - unknown user", mocks.MockAdminID, "99", `{"role": "coach"}`, http.StatusNotFound},
+		{"should return 403 - member", mocks.MockMemberID, "2", `{"role": "admin"}`, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := execRequest(mux, authorize(t, app, newRequest(tt.target, tt.payload), tt.userID))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+		})
+	}
+}
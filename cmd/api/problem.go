@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/go-playground/validator/v10"
+)
+
+const problemContentType = "application/problem+json"
+
+// jsonIndexPattern matches the array indices in a decoder field path such as
+// items.0.workout_id, which are rewritten to match validator paths.
+var jsonIndexPattern = regexp.MustCompile(`\.(\d+)`)
+
+// problem types returned by the API, clients should branch on these rather
+// than on the title or detail
+const (
+	problemTypeBlank         = "about:blank"
+	problemTypeValidation    = "urn:mfit:problem:validation"
+	problemTypeMalformedBody = "urn:mfit:problem:malformed-body"
+	problemTypeConflict      = "urn:mfit:problem:conflict"
+	problemTypeConstraint    = "urn:mfit:problem:constraint"
+)
+
+// constraintFields describes the field at fault for each kind of rejected
+// write by a tag and a message, in the same shape as validator failures.
+var constraintFields = map[error]FieldError{
+	store.ErrDuplicate:    {Tag: "unique", Message: "already exists"},
+	store.ErrForeignKey:   {Tag: "exists", Message: "does not refer to an existing resource"},
+	store.ErrCheck:        {Tag: "check", Message: "is out of the allowed range"},
+	store.ErrNotNull:      {Tag: "required", Message: "is required"},
+	store.ErrInvalidValue: {Tag: "type", Message: "is not a valid value"},
+	store.ErrValueTooLong: {Tag: "max", Message: "is too long"},
+}
+
+// Problem is an RFC 9457 problem details body. Fields lists every field of
+// the payload that failed validation.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Fields    []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError describes a single invalid field by its JSON name, the
+// validation rule it broke and a message meant for people.
+type FieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Message string `json:"message"`
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+// newFieldError reports a check made by a handler after validation in the
+// same shape as a validator failure.
+func newFieldError(field, tag, message string) error {
+	return &FieldError{Field: field, Tag: tag, Message: message}
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, p Problem) error {
+	p.RequestID = getRequestIDFromContext(r.Context())
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(p.Status)
+
+	return json.NewEncoder(w).Encode(p)
+}
+
+// newStatusProblem describes a failure its status already explains, detail
+// adds what the client needs to act on it.
+func newStatusProblem(status int, detail string) Problem {
+	return Problem{
+		Type:   problemTypeBlank,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// newProblem describes err for the client, picking out the fields of
+// validation and JSON decoding errors.
+func newProblem(status int, err error) Problem {
+	p := Problem{
+		Type:   problemTypeBlank,
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+
+	var (
+		validationErrs validator.ValidationErrors
+		fieldErr       *FieldError
+		syntaxErr      *json.SyntaxError
+		typeErr        *json.UnmarshalTypeError
+		maxBytesErr    *http.MaxBytesError
+		constraintErr  *store.ConstraintError
+	)
+
+	switch {
+	case errors.As(err, &constraintErr):
+		p.Type = problemTypeConstraint
+		if status == http.StatusConflict {
+			p.Type = problemTypeConflict
+		}
+		p.Detail = constraintErr.Err.Error()
+		if field, ok := constraintFields[constraintErr.Err]; ok && constraintErr.Column != "" {
+			field.Field = constraintErr.Column
+			p.Fields = []FieldError{field}
+		}
+	case errors.Is(err, store.ErrDuplicate), errors.Is(err, store.ErrSerialization):
+		p.Type = problemTypeConflict
+		p.Detail = err.Error()
+	case errors.As(err, &validationErrs):
+		p.Type = problemTypeValidation
+		p.Title = "Validation failed"
+		for _, fe := range validationErrs {
+			p.Fields = append(p.Fields, FieldError{
+				Field:   fieldName(fe),
+				Tag:     fe.Tag(),
+				Message: fieldMessage(fe),
+			})
+		}
+	case errors.As(err, &fieldErr):
+		p.Type = problemTypeValidation
+		p.Title = "Validation failed"
+		p.Fields = []FieldError{*fieldErr}
+	case errors.As(err, &typeErr):
+		p.Type = problemTypeValidation
+		p.Title = "Validation failed"
+		p.Fields = []FieldError{{
+			Field:   jsonIndexPattern.ReplaceAllString(typeErr.Field, "[$1]"),
+			Tag:     "type",
+			Message: fmt.Sprintf("must be %s, got %s", jsonTypeName(typeErr.Type), typeErr.Value),
+		}}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		p.Type = problemTypeValidation
+		p.Title = "Validation failed"
+		p.Fields = []FieldError{{Field: field, Tag: "unknown", Message: "is not a known field"}}
+	case errors.As(err, &syntaxErr):
+		p.Type = problemTypeMalformedBody
+		p.Title = "Malformed request body"
+		p.Detail = fmt.Sprintf("body contains badly-formed JSON at character %d", syntaxErr.Offset)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		p.Type = problemTypeMalformedBody
+		p.Title = "Malformed request body"
+		p.Detail = "body contains badly-formed JSON"
+	case errors.Is(err, io.EOF):
+		p.Type = problemTypeMalformedBody
+		p.Title = "Malformed request body"
+		p.Detail = "body must not be empty"
+	case errors.Is(err, errTrailingData):
+		p.Type = problemTypeMalformedBody
+		p.Title = "Malformed request body"
+		p.Detail = err.Error()
+	case errors.As(err, &maxBytesErr):
+		p.Type = problemTypeMalformedBody
+		p.Title = "Malformed request body"
+		p.Detail = fmt.Sprintf("body must not be larger than %d bytes", maxBytesErr.Limit)
+	default:
+		p.Detail = err.Error()
+	}
+
+	return p
+}
+
+// fieldName is the JSON path of the field without the payload struct name,
+// e.g. items[0].workout_id.
+func fieldName(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.IndexByte(ns, '.'); i >= 0 {
+		return ns[i+1:]
+	}
+	return ns
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	param := fe.Param()
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "required_with":
+		return "is required when " + param + " is set"
+	case "required_without_all":
+		return "is required when none of " + param + " are set"
+	case "email":
+		return "must be a valid email address"
+	case "numeric":
+		return "must contain only digits"
+	case "unique":
+		return "must not contain duplicates"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(param, " ", ", ")
+	case "gtfield":
+		return "must be greater than " + strings.ToLower(param)
+	case "len":
+		return "must be exactly " + param + sizeUnit(fe.Kind(), param)
+	case "min", "gte":
+		return "must be at least " + param + sizeUnit(fe.Kind(), param)
+	case "max", "lte":
+		return "must be at most " + param + sizeUnit(fe.Kind(), param)
+	case "gt":
+		return "must be greater than " + param + sizeUnit(fe.Kind(), param)
+	case "lt":
+		return "must be less than " + param + sizeUnit(fe.Kind(), param)
+	default:
+		return "failed the " + fe.Tag() + " check"
+	}
+}
+
+// sizeUnit names what a length rule counts for strings and collections.
+func sizeUnit(kind reflect.Kind, param string) string {
+	var unit string
+	switch kind {
+	case reflect.String:
+		unit = " character"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " item"
+	default:
+		return ""
+	}
+
+	if param != "1" {
+		unit += "s"
+	}
+	return unit
+}
+
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
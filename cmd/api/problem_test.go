@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/logger"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+func TestValidationProblem(t *testing.T) {
+	app := newRoutineTestApplication(t)
+	mux := app.mount()
+
+	tests := []struct {
+		name           string
+		payload        string
+		expectedType   string
+		expectedFields []FieldError
+	}{
+		{"should name nested fields by json path",
+			`{"name": "Push", "items": [{"workout_id": 1, "sets": 3, "rest_seconds": 900}]}`,
+			problemTypeValidation,
+			[]FieldError{{"items[0].rest_seconds", "lte", "must be at most 600"}},
+		},
+		{"should report every failed field",
+			`{"name": "", "items": []}`,
+			problemTypeValidation,
+			[]FieldError{
+				{"name", "required", "is required"},
+				{"items", "min", "must be at least 1 item"},
+			},
+		},
+		{"should report handler checks as fields",
+			`{"name": "Push", "items": [{"workout_id": 1, "sets": 3, "reps_min": 12, "reps_max": 8}]}`,
+			problemTypeValidation,
+			[]FieldError{{"items[0].reps_max", "gtefield", "must not be below reps_min"}},
+		},
+		{"should report unknown workouts as fields",
+			fmt.Sprintf(`{"name": "Push", "items": [{"workout_id": %d, "sets": 3}]}`, mocks.MockMissingWorkoutID),
+			problemTypeValidation,
+			[]FieldError{{"items[0].workout_id", "exists", "is not a known workout"}},
+		},
+		{"should report decode type errors as fields",
+			`{"name": "Push", "items": [{"workout_id": "one", "sets": 3}]}`,
+			problemTypeValidation,
+			[]FieldError{{"items[0].workout_id", "type", "must be an integer, got string"}},
+		},
+		{"should report unknown fields",
+			`{"name": "Push", "colour": "red", "items": [{"workout_id": 1, "sets": 3}]}`,
+			problemTypeValidation,
+			[]FieldError{{"colour", "unknown", "is not a known field"}},
+		},
+		{"should report malformed json without fields",
+			`{"name": "Push",`,
+			problemTypeMalformedBody,
+			nil,
+		},
+		{"should report an empty body without fields",
+			``,
+			problemTypeMalformedBody,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(http.MethodPost, RoutinesUrl, bytes.NewBufferString(tt.payload))
+			req.Header.Set(requestIDHeader, "test-request")
+
+			res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+			assertStatusCode(t, res.Code, http.StatusBadRequest)
+			assertContentType(t, res.Header().Get("Content-Type"), problemContentType)
+
+			var problem Problem
+			if err := json.NewDecoder(res.Body).Decode(&problem); err != nil {
+				t.Fatal(err)
+			}
+
+			if problem.Type != tt.expectedType {
+				t.Errorf("got type %q want %q", problem.Type, tt.expectedType)
+			}
+
+			if problem.Status != http.StatusBadRequest {
+				t.Errorf("got status %d want %d", problem.Status, http.StatusBadRequest)
+			}
+
+			if problem.RequestID != "test-request" {
+				t.Errorf("got request id %q want %q", problem.RequestID, "test-request")
+			}
+
+			if !reflect.DeepEqual(problem.Fields, tt.expectedFields) {
+				t.Errorf("got fields %+v want %+v", problem.Fields, tt.expectedFields)
+			}
+		})
+	}
+}
+
+func TestStoreError(t *testing.T) {
+	app := newTestApplication(t, store.Storage{})
+	testLogger, logs := logger.NewTestLogger()
+	app.logger = testLogger
+
+	tests := []struct {
+		name               string
+		err                error
+		expectedStatusCode int
+		expectedType       string
+		expectedFields     []FieldError
+	}{
+		{"should return 409 - duplicate name",
+			&store.ConstraintError{Err: store.ErrDuplicate, Table: "body_part", Column: "name"},
+			http.StatusConflict,
+			problemTypeConflict,
+			[]FieldError{{"name", "unique", "already exists"}},
+		},
+		{"should return 409 - serialization failure",
+			store.ErrSerialization,
+			http.StatusConflict,
+			problemTypeConflict,
+			nil,
+		},
+		{"should return 422 - unknown body part",
+			&store.ConstraintError{Err: store.ErrForeignKey, Table: "target", Column: "bodypart_id"},
+			http.StatusUnprocessableEntity,
+			problemTypeConstraint,
+			[]FieldError{{"bodypart_id", "exists", "does not refer to an existing resource"}},
+		},
+		{"should return 422 - check without a column",
+			&store.ConstraintError{Err: store.ErrCheck, Table: "routine_item", Constraint: "reps_in_order"},
+			http.StatusUnprocessableEntity,
+			problemTypeConstraint,
+			nil,
+		},
+		{"should return 500 - anything else",
+			errors.New("connection refused"),
+			http.StatusInternalServerError,
+			problemTypeBlank,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, BodyPartUrl, nil)
+			res := httptest.NewRecorder()
+
+			app.storeError(res, req, tt.err)
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			entries := logs.TakeAll()
+			if len(entries) != 1 {
+				t.Fatalf("got %d log entries want 1", len(entries))
+			}
+
+			if got := entries[0].ContextMap()["error"]; got != tt.err.Error() {
+				t.Errorf("got logged error %v want %q", got, tt.err.Error())
+			}
+
+			assertContentType(t, res.Header().Get("Content-Type"), problemContentType)
+
+			var problem Problem
+			if err := json.NewDecoder(res.Body).Decode(&problem); err != nil {
+				t.Fatal(err)
+			}
+
+			if problem.Type != tt.expectedType {
+				t.Errorf("got type %q want %q", problem.Type, tt.expectedType)
+			}
+
+			if !reflect.DeepEqual(problem.Fields, tt.expectedFields) {
+				t.Errorf("got fields %+v want %+v", problem.Fields, tt.expectedFields)
+			}
+		})
+	}
+}
+
+func TestErrorHelpersWriteProblems(t *testing.T) {
+	app := newTestApplication(t, store.Storage{})
+
+	tests := []struct {
+		name               string
+		write              func(http.ResponseWriter, *http.Request)
+		expectedStatusCode int
+		expectedType       string
+	}{
+		{"should return 401 - unauthorized", func(w http.ResponseWriter, r *http.Request) {
+			app.unauthorizedError(w, r, errors.New("missing token"))
+		}, http.StatusUnauthorized, problemTypeBlank},
+		{"should return 401 - invalid credentials", app.invalidCredentials, http.StatusUnauthorized, problemTypeBlank},
+		{"should return 403 - forbidden", func(w http.ResponseWriter, r *http.Request) {
+			app.forbiddenError(w, r, "insufficient permissions")
+		}, http.StatusForbidden, problemTypeBlank},
+		{"should return 404 - not found", app.notFound, http.StatusNotFound, problemTypeBlank},
+		{"should return 409 - conflict", func(w http.ResponseWriter, r *http.Request) {
+			app.conflictError(w, r, store.ErrSessionFinished)
+		}, http.StatusConflict, problemTypeConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, BodyPartUrl, nil)
+			res := httptest.NewRecorder()
+
+			tt.write(res, req)
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+			assertContentType(t, res.Header().Get("Content-Type"), problemContentType)
+
+			var problem Problem
+			if err := json.NewDecoder(res.Body).Decode(&problem); err != nil {
+				t.Fatal(err)
+			}
+
+			if problem.Type != tt.expectedType || problem.Status != tt.expectedStatusCode {
+				t.Errorf("got type %q status %d want %q %d", problem.Type, problem.Status, tt.expectedType, tt.expectedStatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/units"
+)
+
+// Plausible body metric ranges, checked after converting to metric.
+const (
+	minHeightCm = 50
+	maxHeightCm = 275
+	minWeightKg = 20
+	maxWeightKg = 500
+
+	// storedMeasurementPlaces matches the numeric(7, 3) columns, responses
+	// are rounded to one place in the caller's units
+	storedMeasurementPlaces = 3
+)
+
+// ProfileResponse presents a profile in the user's preferred units.
+type ProfileResponse struct {
+	UserID          int64    `json:"user_id"`
+	Height          *float64 `json:"height"`
+	Weight          *float64 `json:"weight"`
+	BirthYear       *int     `json:"birth_year"`
+	Sex             *string  `json:"sex"`
+	ExperienceLevel *string  `json:"experience_level"`
+	Goal            *string  `json:"goal"`
+	WeightUnit      string   `json:"weight_unit"`
+	LengthUnit      string   `json:"length_unit"`
+	UpdatedAt       string   `json:"updated_at,omitempty"`
+}
+
+func newProfileResponse(profile *store.Profile) (*ProfileResponse, error) {
+	res := &ProfileResponse{
+		UserID:          profile.UserID,
+		BirthYear:       profile.BirthYear,
+		Sex:             profile.Sex,
+		ExperienceLevel: profile.ExperienceLevel,
+		Goal:            profile.Goal,
+		WeightUnit:      profile.WeightUnit,
+		LengthUnit:      profile.LengthUnit,
+		UpdatedAt:       profile.UpdatedAt,
+	}
+
+	if profile.HeightCm != nil {
+		height, err := units.FromCentimeters(*profile.HeightCm, profile.LengthUnit)
+		if err != nil {
+			return nil, err
+		}
+		height = units.Round(height, 1)
+		res.Height = &height
+	}
+
+	if profile.WeightKg != nil {
+		weight, err := units.FromKilograms(*profile.WeightKg, profile.WeightUnit)
+		if err != nil {
+			return nil, err
+		}
+		weight = units.Round(weight, 1)
+		res.Weight = &weight
+	}
+
+	return res, nil
+}
+
+// getProfile returns the user's profile, or an empty one with metric units
+// if they have not saved one yet.
+func (app *application) getProfile(r *http.Request, userID int64) (*store.Profile, error) {
+	profile, err := app.store.Profiles.GetByUserID(r.Context(), userID)
+	if err == store.ErrNotFound {
+		return &store.Profile{UserID: userID, WeightUnit: units.Kilogram, LengthUnit: units.Centimeter}, nil
+	}
+	return profile, err
+}
+
+// GetProfile godoc
+//
+//	@Summary		Fetches the current user's profile
+//	@Description	Fetches the current user's fitness profile, with height and weight in their preferred units
+//	@Tags			profile
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	ProfileResponse
+//	@Failure		401	{object}	Problem
+//	@Failure		403	{object}	Problem
+//	@Failure		500	{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/me/profile [get]
+func (app *application) getProfileHandler(w http.ResponseWriter, r *http.Request) {
+	user := getUserFromContext(r)
+
+	profile, err := app.getProfile(r, user.ID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	res, err := newProfileResponse(profile)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, res); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// UpdateProfilePayload takes height and weight in the units given alongside
+// them, or the saved preference when omitted.
+type UpdateProfilePayload struct {
+	Height          *float64 `json:"height" validate:"omitnil,gt=0"`
+	Weight          *float64 `json:"weight" validate:"omitnil,gt=0"`
+	BirthYear       *int     `json:"birth_year" validate:"omitnil,gte=1900"`
+	Sex             *string  `json:"sex" validate:"omitnil,oneof=female male other"`
+	ExperienceLevel *string  `json:"experience_level" validate:"omitnil,oneof=beginner intermediate advanced"`
+	Goal            *string  `json:"goal" validate:"omitnil,oneof=strength hypertrophy endurance fat_loss general_fitness"`
+	WeightUnit      *string  `json:"weight_unit" validate:"omitnil,oneof=kg lb"`
+	LengthUnit      *string  `json:"length_unit" validate:"omitnil,oneof=cm in"`
+}
+
+// UpdateProfile godoc
+//
+//	@Summary		Updates the current user's profile
+//	@Description	Updates the current user's fitness profile, creating it on first use
+//	@Tags			profile
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		UpdateProfilePayload	true	"Profile payload"
+//	@Success		200		{object}	ProfileResponse
+//	@Failure		400		{object}	Problem
+//	@Failure		401		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/me/profile [patch]
+func (app *application) updateProfileHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := getUserFromContext(r)
+
+	payload, err := decodeJSON[UpdateProfilePayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	profile, err := app.getProfile(r, user.ID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if payload.WeightUnit != nil {
+		profile.WeightUnit = *payload.WeightUnit
+	}
+
+	if payload.LengthUnit != nil {
+		profile.LengthUnit = *payload.LengthUnit
+	}
+
+	if payload.Height != nil {
+		height, err := units.ToCentimeters(*payload.Height, profile.LengthUnit)
+		if err != nil {
+			app.badRequest(w, r, err)
+			return
+		}
+
+		if height < minHeightCm || height > maxHeightCm {
+			app.badRequest(w, r, newFieldError("height", "range", fmt.Sprintf("must be between %d and %d cm", minHeightCm, maxHeightCm)))
+			return
+		}
+
+		height = units.Round(height, storedMeasurementPlaces)
+		profile.HeightCm = &height
+	}
+
+	if payload.Weight != nil {
+		weight, err := units.ToKilograms(*payload.Weight, profile.WeightUnit)
+		if err != nil {
+			app.badRequest(w, r, err)
+			return
+		}
+
+		if weight < minWeightKg || weight > maxWeightKg {
+			app.badRequest(w, r, newFieldError("weight", "range", fmt.Sprintf("must be between %d and %d kg", minWeightKg, maxWeightKg)))
+			return
+		}
+
+		weight = units.Round(weight, storedMeasurementPlaces)
+		profile.WeightKg = &weight
+	}
+
+	if payload.BirthYear != nil {
+		if *payload.BirthYear > time.Now().Year() {
+			app.badRequest(w, r, newFieldError("birth_year", "past", "must not be in the future"))
+			return
+		}
+		profile.BirthYear = payload.BirthYear
+	}
+
+	if payload.Sex != nil {
+		profile.Sex = payload.Sex
+	}
+
+	if payload.ExperienceLevel != nil {
+		profile.ExperienceLevel = payload.ExperienceLevel
+	}
+
+	if payload.Goal != nil {
+		profile.Goal = payload.Goal
+	}
+
+	if err := app.store.Profiles.Upsert(ctx, profile); err != nil {
+		app.storeError(w, r, err)
+		return
+	}
+
+	res, err := newProfileResponse(profile)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, res); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+var ProfileUrl = newCollectionPath("me/profile")
+
+func TestGetProfile(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		Users:    new(mocks.MockUserStore),
+		Profiles: new(mocks.MockProfileStore),
+		APIKeys:  new(mocks.MockAPIKeyStore),
+	})
+	mux := app.mount()
+
+	t.Run("should return 200 - saved profile", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, ProfileUrl, nil)
+
+		res := execRequest(mux, authorize(t, app, req, mocks.MockAdminID))
+
+		assertStatusCode(t, res.Code, http.StatusOK)
+		assertResponse(t, res.Body, []byte(`{"data": {"user_id": 1, "height": 180, "weight": 80, "birth_year": 1990, "sex": null, "experience_level": "intermediate", "goal": null, "weight_unit": "kg", "length_unit": "cm"}}`))
+	})
+
+	t.Run("should return 200 - empty profile", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, ProfileUrl, nil)
+
+		res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+		assertStatusCode(t, res.Code, http.StatusOK)
+		assertResponse(t, res.Body, []byte(`{"data": {"user_id": 2, "height": null, "weight": null, "birth_year": null, "sex": null, "experience_level": null, "goal": null, "weight_unit": "kg", "length_unit": "cm"}}`))
+	})
+
+	t.Run("should return 401 - anonymous", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, ProfileUrl, nil)
+
+		res := execRequest(mux, req)
+
+		assertStatusCode(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("should return 403 - api key", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, ProfileUrl, nil)
+		req.Header.Set("X-API-Key", mocks.MockAPIKey)
+
+		res := execRequest(mux, req)
+
+		assertStatusCode(t, res.Code, http.StatusForbidden)
+	})
+}
+
+func TestUpdateProfile(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		Users:    new(mocks.MockUserStore),
+		Profiles: new(mocks.MockProfileStore),
+	})
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		userID             int64
+		payload            string
+		expectedStatusCode int
+		expectedHeight     *float64
+		expectedWeight     *float64
+	}{
+		{"should return 200 - pounds in and out", mocks.MockMemberID, `{"weight": 176.4, "weight_unit": "lb", "experience_level": "beginner"}`, http.StatusOK, nil, ptr(176.4)},
+		{"should return 200 - whole pounds round trip", mocks.MockMemberID, `{"weight": 180, "height": 71, "weight_unit": "lb", "length_unit": "in"}`, http.StatusOK, ptr(71.0), ptr(180.0)},
+		{"should return 200 - switching units converts saved values", mocks.MockAdminID, `{"weight_unit": "lb", "length_unit": "in"}`, http.StatusOK, ptr(70.9), ptr(176.4)},
+		{"should return 200 - input uses saved units", mocks.MockAdminID, `{"height": 182.5}`, http.StatusOK, ptr(182.5), ptr(80.0)},
+		{"should return 400 - weight out of range", mocks.MockMemberID, `{"weight": 15}`, http.StatusBadRequest, nil, nil},
+		{"should return 400 - unknown unit", mocks.MockMemberID, `{"weight_unit": "stone"}`, http.StatusBadRequest, nil, nil},
+		{"should return 400 - unknown level", mocks.MockMemberID, `{"experience_level": "elite"}`, http.StatusBadRequest, nil, nil},
+		{"should return 400 - future birth year", mocks.MockMemberID, `{"birth_year": 3000}`, http.StatusBadRequest, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(http.MethodPatch, ProfileUrl, bytes.NewReader([]byte(tt.payload)))
+
+			res := execRequest(mux, authorize(t, app, req, tt.userID))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			if tt.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Data ProfileResponse `json:"data"`
+			}
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			assertFloatPtr(t, "height", body.Data.Height, tt.expectedHeight)
+			assertFloatPtr(t, "weight", body.Data.Weight, tt.expectedWeight)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func assertFloatPtr(t testing.TB, name string, got, want *float64) {
+	t.Helper()
+
+	switch {
+	case got == nil && want == nil:
+	case got == nil || want == nil:
+		t.Errorf("got %s %v want %v", name, got, want)
+	case *got != *want:
+		t.Errorf("got %s %v want %v", name, *got, *want)
+	}
+}
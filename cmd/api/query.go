@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+const defaultAutocompleteLimit = 10
+
+// parsePaginatedQuery reads limit, cursor and sort from the query string and
+// checks them against the sorts the collection supports.
+func parsePaginatedQuery(r *http.Request, sorts store.SortColumns) (store.PaginatedQuery, error) {
+	qs := r.URL.Query()
+
+	var q store.PaginatedQuery
+
+	var err error
+	if q.Limit, err = parseIntQuery(qs, "limit", store.DefaultPageLimit); err != nil {
+		return q, err
+	}
+
+	q.Sort = store.DefaultSort
+	if sort := qs.Get("sort"); sort != "" {
+		q.Sort = sort
+	}
+
+	q.Cursor = qs.Get("cursor")
+
+	if err := q.Check(sorts); err != nil {
+		return q, err
+	}
+
+	return q, nil
+}
+
+// parseWorkoutFilter reads the workout filters from the query string.
+// Filters that are absent keep their zero value, which matches every workout.
+func parseWorkoutFilter(r *http.Request) (store.WorkoutFilter, error) {
+	qs := r.URL.Query()
+
+	var f store.WorkoutFilter
+
+	var err error
+	if f.BodyPartID, err = parseInt64Query(qs, "bodypart_id"); err != nil {
+		return f, err
+	}
+
+	if f.EquipmentID, err = parseInt64Query(qs, "equipment_id"); err != nil {
+		return f, err
+	}
+
+	if f.TargetID, err = parseInt64Query(qs, "target_id"); err != nil {
+		return f, err
+	}
+
+	f.Difficulty = qs.Get("difficulty")
+	f.TargetType = qs.Get("target_type")
+
+	return f, nil
+}
+
+func parseTargetFilter(r *http.Request) (store.TargetFilter, error) {
+	qs := r.URL.Query()
+
+	var f store.TargetFilter
+
+	var err error
+	if f.BodyPartID, err = parseInt64Query(qs, "bodypart_id"); err != nil {
+		return f, err
+	}
+
+	return f, nil
+}
+
+// parseSessionFilter reads from and to as RFC 3339 timestamps or plain dates.
+// A plain to date includes the whole day.
+func parseSessionFilter(r *http.Request) (store.SessionFilter, error) {
+	qs := r.URL.Query()
+
+	var f store.SessionFilter
+
+	var err error
+	if f.From, err = parseTimeQuery(qs, "from", false); err != nil {
+		return f, err
+	}
+
+	if f.To, err = parseTimeQuery(qs, "to", true); err != nil {
+		return f, err
+	}
+
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return f, fmt.Errorf("from must be before to")
+	}
+
+	return f, nil
+}
+
+func parseSearchQuery(r *http.Request) (store.SearchQuery, error) {
+	qs := r.URL.Query()
+
+	q := store.SearchQuery{Query: strings.TrimSpace(qs.Get("q"))}
+
+	var err error
+	if q.Limit, err = parseIntQuery(qs, "limit", store.DefaultPageLimit); err != nil {
+		return q, err
+	}
+
+	return q, nil
+}
+
+func parseAutocompleteQuery(r *http.Request) (store.AutocompleteQuery, error) {
+	qs := r.URL.Query()
+
+	q := store.AutocompleteQuery{Query: strings.TrimSpace(qs.Get("q"))}
+
+	q.Types = store.AutocompleteTypes
+	if types := qs.Get("types"); types != "" {
+		q.Types = strings.Split(types, ",")
+	}
+
+	var err error
+	if q.Limit, err = parseIntQuery(qs, "limit", defaultAutocompleteLimit); err != nil {
+		return q, err
+	}
+
+	return q, nil
+}
+
+func parseIntQuery(qs url.Values, key string, fallback int) (int, error) {
+	val := qs.Get(key)
+	if val == "" {
+		return fallback, nil
+	}
+
+	i, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s query parameter: %q", key, val)
+	}
+
+	return i, nil
+}
+
+func parseInt64Query(qs url.Values, key string) (int64, error) {
+	val := qs.Get(key)
+	if val == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s query parameter: %q", key, val)
+	}
+
+	return i, nil
+}
+
+func parseTimeQuery(qs url.Values, key string, endOfDay bool) (*time.Time, error) {
+	val := qs.Get(key)
+	if val == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, val); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, val)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s query parameter: %q", key, val)
+	}
+
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return &t, nil
+}
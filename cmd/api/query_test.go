@@ -0,0 +1,87 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+func TestParsePaginatedQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    store.PaginatedQuery
+		wantErr bool
+	}{
+		{"defaults", "", store.PaginatedQuery{Limit: 20, Sort: "id"}, false},
+		{"descending sort", "?limit=5&sort=-name", store.PaginatedQuery{Limit: 5, Sort: "-name"}, false},
+		{"non numeric limit", "?limit=ten", store.PaginatedQuery{}, true},
+		{"unknown sort", "?sort=image_url", store.PaginatedQuery{}, true},
+		{"malformed cursor", "?cursor=!!!", store.PaginatedQuery{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/bodyparts"+tt.query, nil)
+
+			got, err := parsePaginatedQuery(r, store.BodyPartSorts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("got %+v want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSessionFilter(t *testing.T) {
+	day := func(d int) *time.Time {
+		t := time.Date(2026, time.January, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		wantFrom *time.Time
+		wantTo   *time.Time
+		wantErr  bool
+	}{
+		{"open range", "", nil, nil, false},
+		{"dates include the whole to day", "?from=2026-01-01&to=2026-01-07", day(1), day(8), false},
+		{"timestamps are taken as is", "?from=2026-01-01T00:00:00Z&to=2026-01-07T00:00:00Z", day(1), day(7), false},
+		{"only from", "?from=2026-01-03", day(3), nil, false},
+		{"same day", "?from=2026-01-03&to=2026-01-03", day(3), day(4), false},
+		{"from after to", "?from=2026-01-07&to=2026-01-01", nil, nil, true},
+		{"invalid date", "?from=last-week", nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/sessions"+tt.query, nil)
+
+			f, err := parseSessionFilter(r)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertTime(t, "from", f.From, tt.wantFrom)
+			assertTime(t, "to", f.To, tt.wantTo)
+		})
+	}
+}
+
+func assertTime(t testing.TB, name string, got, want *time.Time) {
+	t.Helper()
+
+	if (got == nil) != (want == nil) || (got != nil && !got.Equal(*want)) {
+		t.Errorf("%s: got %v want %v", name, got, want)
+	}
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/JerryLegend254/mfit_api/internal/metrics"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/go-chi/chi/v5"
+)
+
+type routineContextKey string
+
+var routineCtxKey routineContextKey = "routine"
+
+var errInvalidRoutineItem = errors.New("invalid routine item")
+
+type RoutineItemPayload struct {
+	WorkoutID       int64    `json:"workout_id" validate:"required,gt=0"`
+	Sets            int      `json:"sets" validate:"required,gte=1,lte=20"`
+	RepsMin         *int     `json:"reps_min" validate:"omitnil,gte=1,lte=100"`
+	RepsMax         *int     `json:"reps_max" validate:"omitnil,gte=1,lte=100"`
+	TargetLoadKg    *float64 `json:"target_load_kg" validate:"omitnil,gte=0,lte=1000"`
+	DurationSeconds *int     `json:"duration_seconds" validate:"omitnil,gte=1,lte=3600"`
+	RestSeconds     *int     `json:"rest_seconds" validate:"omitnil,gte=0,lte=600"`
+}
+
+type CreateRoutinePayload struct {
+	Name        string               `json:"name" validate:"required,max=100"`
+	Description string               `json:"description" validate:"max=500"`
+	Items       []RoutineItemPayload `json:"items" validate:"required,min=1,max=50,dive"`
+}
+
+func (p *CreateRoutinePayload) normalize() {
+	p.Name = strings.TrimSpace(p.Name)
+	p.Description = strings.TrimSpace(p.Description)
+}
+
+// defaultRestSeconds applies to items that do not set rest_seconds.
+const defaultRestSeconds = 60
+
+// CreateRoutine godoc
+//
+//	@Summary		Creates a routine
+//	@Description	Creates a routine for the current user from catalog workouts, items keep the order given
+//	@Tags			routines
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		CreateRoutinePayload	true	"Routine payload"
+//	@Success		201		{object}	store.Routine
+//	@Failure		400		{object}	Problem
+//	@Failure		401		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/routines [post]
+func (app *application) createRoutineHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := getUserFromContext(r)
+
+	payload, err := decodeJSON[CreateRoutinePayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	items, err := app.newRoutineItems(ctx, payload.Items)
+	if err != nil {
+		app.routineItemsError(w, r, err)
+		return
+	}
+
+	routine := &store.Routine{
+		UserID:      user.ID,
+		Name:        payload.Name,
+		Description: payload.Description,
+		Items:       items,
+	}
+
+	if err := app.store.Routines.Create(ctx, routine); err != nil {
+		app.routineItemsError(w, r, err)
+		return
+	}
+	metrics.RoutinesCreated.Inc()
+	routine.EstimatedDurationSeconds = routine.EstimateDuration()
+
+	if err := app.jsonResponse(w, http.StatusCreated, routine); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// FetchRoutines godoc
+//
+//	@Summary		Fetches routines
+//	@Description	Fetches the current user's routines with their items expanded
+//	@Tags			routines
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	[]store.Routine
+//	@Failure		401	{object}	Problem
+//	@Failure		403	{object}	Problem
+//	@Failure		500	{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/routines [get]
+func (app *application) fetchRoutinesHandler(w http.ResponseWriter, r *http.Request) {
+	user := getUserFromContext(r)
+
+	routines, err := app.store.Routines.GetAllByUser(r.Context(), user.ID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, routines); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// GetRoutine godoc
+//
+//	@Summary		Fetches a routine
+//	@Description	Fetches one of the current user's routines by ID
+//	@Tags			routines
+//	@Accept			json
+//	@Produce		json
+//	@Param			routineId	path		int	true	"Routine ID"
+//	@Success		200			{object}	store.Routine
+//	@Failure		401			{object}	Problem
+//	@Failure		403			{object}	Problem
+//	@Failure		404			{object}	Problem
+//	@Failure		500			{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/routines/{routineId} [get]
+func (app *application) getRoutineHandler(w http.ResponseWriter, r *http.Request) {
+	routine := getRoutineFromContext(r)
+
+	if err := app.jsonResponse(w, http.StatusOK, routine); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// UpdateRoutinePayload replaces all of the routine's items when items is set.
+type UpdateRoutinePayload struct {
+	Name        *string               `json:"name" validate:"omitnil,min=1,max=100"`
+	Description *string               `json:"description" validate:"omitnil,max=500"`
+	Items       *[]RoutineItemPayload `json:"items" validate:"omitnil,min=1,max=50,dive"`
+}
+
+func (p *UpdateRoutinePayload) normalize() {
+	trimString(p.Name)
+	trimString(p.Description)
+}
+
+// UpdateRoutine godoc
+//
+//	@Summary		Updates a routine
+//	@Description	Updates one of the current user's routines, a list of items replaces the existing ones
+//	@Tags			routines
+//	@Accept			json
+//	@Produce		json
+//	@Param			routineId	path		int						true	"Routine ID"
+//	@Param			payload		body		UpdateRoutinePayload	true	"Routine payload"
+//	@Success		200			{object}	store.Routine
+//	@Failure		400			{object}	Problem
+//	@Failure		401			{object}	Problem
+//	@Failure		403			{object}	Problem
+//	@Failure		404			{object}	Problem
+//	@Failure		500			{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/routines/{routineId} [patch]
+func (app *application) updateRoutineHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	routine := getRoutineFromContext(r)
+
+	payload, err := decodeJSON[UpdateRoutinePayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	if payload.Name != nil {
+		routine.Name = *payload.Name
+	}
+
+	if payload.Description != nil {
+		routine.Description = *payload.Description
+	}
+
+	if payload.Items != nil {
+		items, err := app.newRoutineItems(ctx, *payload.Items)
+		if err != nil {
+			app.routineItemsError(w, r, err)
+			return
+		}
+		routine.Items = items
+	}
+
+	if err := app.store.Routines.Update(ctx, routine); err != nil {
+		switch err {
+		case store.ErrNotFound:
+			app.notFound(w, r)
+		default:
+			app.routineItemsError(w, r, err)
+		}
+		return
+	}
+	routine.EstimatedDurationSeconds = routine.EstimateDuration()
+
+	if err := app.jsonResponse(w, http.StatusOK, routine); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// DeleteRoutine godoc
+//
+//	@Summary		Deletes a routine
+//	@Description	Deletes one of the current user's routines by ID
+//	@Tags			routines
+//	@Accept			json
+//	@Produce		json
+//	@Param			routineId	path	int	true	"Routine ID"
+//	@Success		204
+//	@Failure		401	{object}	Problem
+//	@Failure		403	{object}	Problem
+//	@Failure		404	{object}	Problem
+//	@Failure		500	{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/routines/{routineId} [delete]
+func (app *application) deleteRoutineHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	routine := getRoutineFromContext(r)
+
+	if err := app.store.Routines.Delete(ctx, routine.ID); err != nil {
+		switch err {
+		case store.ErrNotFound:
+			app.notFound(w, r)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// newRoutineItems checks the payload items against each other and the
+// catalog, and returns them with their workouts attached.
+func (app *application) newRoutineItems(ctx context.Context, payload []RoutineItemPayload) ([]store.RoutineItem, error) {
+	ids := make([]int64, 0, len(payload))
+	for i, p := range payload {
+		if p.RepsMin != nil && p.RepsMax != nil && *p.RepsMax < *p.RepsMin {
+			return nil, fmt.Errorf("%w: %w", errInvalidRoutineItem, newFieldError(fmt.Sprintf("items[%d].reps_max", i), "gtefield", "must not be below reps_min"))
+		}
+		ids = append(ids, p.WorkoutID)
+	}
+
+	workouts, err := app.store.Workouts.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*store.PresentableWorkout, len(workouts))
+	for i := range workouts {
+		byID[workouts[i].ID] = &workouts[i]
+	}
+
+	items := make([]store.RoutineItem, len(payload))
+	for i, p := range payload {
+		workout, ok := byID[p.WorkoutID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %w", store.ErrUnknownWorkout, newFieldError(fmt.Sprintf("items[%d].workout_id", i), "exists", "is not a known workout"))
+		}
+
+		rest := defaultRestSeconds
+		if p.RestSeconds != nil {
+			rest = *p.RestSeconds
+		}
+
+		items[i] = store.RoutineItem{
+			Position:        i + 1,
+			WorkoutID:       p.WorkoutID,
+			Sets:            p.Sets,
+			RepsMin:         p.RepsMin,
+			RepsMax:         p.RepsMax,
+			TargetLoadKg:    p.TargetLoadKg,
+			DurationSeconds: p.DurationSeconds,
+			RestSeconds:     rest,
+			Workout:         workout,
+		}
+	}
+
+	return items, nil
+}
+
+// routineItemsError reports invalid routine items as bad requests and
+// anything else as a store error.
+func (app *application) routineItemsError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, store.ErrUnknownWorkout), errors.Is(err, errInvalidRoutineItem):
+		app.badRequest(w, r, err)
+	default:
+		app.storeError(w, r, err)
+	}
+}
+
+func (app *application) routineContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id := chi.URLParam(r, "routineId")
+
+		intId, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			app.badRequest(w, r, errors.New("invalid routine id"))
+			return
+		}
+
+		routine, err := app.store.Routines.GetByID(ctx, intId)
+		if err != nil {
+			switch err {
+			case store.ErrNotFound:
+				app.notFound(w, r)
+			default:
+				app.internalServerError(w, r, err)
+			}
+			return
+		}
+
+		// other users' routines are reported as missing rather than forbidden
+		if routine.UserID != getUserFromContext(r).ID {
+			app.notFound(w, r)
+			return
+		}
+
+		ctx = context.WithValue(ctx, routineCtxKey, routine)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func getRoutineFromContext(r *http.Request) *store.Routine {
+	routine, _ := r.Context().Value(routineCtxKey).(*store.Routine)
+	return routine
+}
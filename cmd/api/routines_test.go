@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+var RoutinesUrl = newCollectionPath("routines")
+
+func newRoutineTestApplication(t testing.TB) *application {
+	return newTestApplication(t, store.Storage{
+		Users:    new(mocks.MockUserStore),
+		Workouts: new(mocks.MockWorkoutStore),
+		Routines: new(mocks.MockRoutineStore),
+		APIKeys:  new(mocks.MockAPIKeyStore),
+	})
+}
+
+func TestCreateRoutine(t *testing.T) {
+	app := newRoutineTestApplication(t)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		payload            string
+		expectedStatusCode int
+		expectedDuration   int
+	}{
+		// 5 minutes of work plus two 90 second rests
+		{"should return 201 - catalog duration", `{"name": "Push", "items": [{"workout_id": 1, "sets": 3, "rest_seconds": 90}]}`, http.StatusCreated, 300 + 2*90},
+		// 3×30s work and 3×60s default rest, then 300s of work and one 45s rest
+		{"should return 201 - timed item and default rest", `{"name": "Core", "items": [{"workout_id": 1, "sets": 3, "duration_seconds": 30}, {"workout_id": 2, "sets": 2, "reps_min": 8, "reps_max": 12, "rest_seconds": 45}]}`, http.StatusCreated, 90 + 180 + 300 + 45},
+		{"should return 400 - missing items", `{"name": "Empty", "items": []}`, http.StatusBadRequest, 0},
+		{"should return 400 - missing name", `{"items": [{"workout_id": 1, "sets": 3}]}`, http.StatusBadRequest, 0},
+		{"should return 400 - zero sets", `{"name": "Push", "items": [{"workout_id": 1, "sets": 0}]}`, http.StatusBadRequest, 0},
+		{"should return 400 - reps range reversed", `{"name": "Push", "items": [{"workout_id": 1, "sets": 3, "reps_min": 12, "reps_max": 8}]}`, http.StatusBadRequest, 0},
+		{"should return 400 - unknown workout", fmt.Sprintf(`{"name": "Push", "items": [{"workout_id": %d, "sets": 3}]}`, mocks.MockMissingWorkoutID), http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(http.MethodPost, RoutinesUrl, bytes.NewBufferString(tt.payload))
+
+			res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			if tt.expectedStatusCode != http.StatusCreated {
+				return
+			}
+
+			var body struct {
+				Data store.Routine `json:"data"`
+			}
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			if body.Data.UserID != mocks.MockMemberID {
+				t.Errorf("got user %d want %d", body.Data.UserID, mocks.MockMemberID)
+			}
+
+			if body.Data.EstimatedDurationSeconds != tt.expectedDuration {
+				t.Errorf("got duration %d want %d", body.Data.EstimatedDurationSeconds, tt.expectedDuration)
+			}
+
+			for i, item := range body.Data.Items {
+				if item.Position != i+1 {
+					t.Errorf("item %d: got position %d", i, item.Position)
+				}
+				if item.Workout == nil || item.Workout.ID != item.WorkoutID {
+					t.Errorf("item %d: workout not expanded", i)
+				}
+			}
+		})
+	}
+
+	t.Run("should return 401 - anonymous", func(t *testing.T) {
+		req := newJSONRequest(http.MethodPost, RoutinesUrl, bytes.NewBufferString(`{"name": "Push", "items": [{"workout_id": 1, "sets": 3}]}`))
+
+		res := execRequest(mux, req)
+
+		assertStatusCode(t, res.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("should return 403 - api key", func(t *testing.T) {
+		req := newJSONRequest(http.MethodPost, RoutinesUrl, bytes.NewBufferString(`{"name": "Push", "items": [{"workout_id": 1, "sets": 3}]}`))
+		req.Header.Set("X-API-Key", mocks.MockAPIKey)
+
+		res := execRequest(mux, req)
+
+		assertStatusCode(t, res.Code, http.StatusForbidden)
+	})
+}
+
+func TestRoutineOwnership(t *testing.T) {
+	app := newRoutineTestApplication(t)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		method             string
+		routineID          int64
+		payload            string
+		expectedStatusCode int
+	}{
+		{"should return 200 - own routine", http.MethodGet, mocks.MockMemberRoutineID, "", http.StatusOK},
+		{"should return 404 - other user's routine", http.MethodGet, mocks.MockAdminRoutineID, "", http.StatusNotFound},
+		{"should return 404 - missing routine", http.MethodGet, 99, "", http.StatusNotFound},
+		{"should return 200 - rename", http.MethodPatch, mocks.MockMemberRoutineID, `{"name": "Legs"}`, http.StatusOK},
+		{"should return 400 - replace with unknown workout", http.MethodPatch, mocks.MockMemberRoutineID, fmt.Sprintf(`{"items": [{"workout_id": %d, "sets": 1}]}`, mocks.MockMissingWorkoutID), http.StatusBadRequest},
+		{"should return 404 - update other user's routine", http.MethodPatch, mocks.MockAdminRoutineID, `{"name": "Legs"}`, http.StatusNotFound},
+		{"should return 204 - delete own routine", http.MethodDelete, mocks.MockMemberRoutineID, "", http.StatusNoContent},
+		{"should return 404 - delete other user's routine", http.MethodDelete, mocks.MockAdminRoutineID, "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(tt.method, fmt.Sprintf("%s/%d", RoutinesUrl, tt.routineID), bytes.NewBufferString(tt.payload))
+
+			res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+		})
+	}
+
+	t.Run("should return 200 - list only own routines", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, RoutinesUrl, nil)
+
+		res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+		assertStatusCode(t, res.Code, http.StatusOK)
+
+		var body struct {
+			Data []store.Routine `json:"data"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(body.Data) != 1 || body.Data[0].ID != mocks.MockMemberRoutineID {
+			t.Errorf("got %+v want only routine %d", body.Data, mocks.MockMemberRoutineID)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package main
+
+import "net/http"
+
+// SearchWorkouts godoc
+//
+//	@Summary		Search the exercise catalog
+//	@Description	Full-text search over workout names, instructions and their body part, target and equipment names
+//	@Tags			search
+//	@Accept			json
+//	@Produce		json
+//	@Param			q		query		string	true	"Search terms, supports quoted phrases, OR and -exclusions"
+//	@Param			limit	query		int		false	"Maximum number of results (1-50)"	default(20)
+//	@Success		200		{object}	[]store.WorkoutSearchResult
+//	@Failure		400		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/search [get]
+func (app *application) searchHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	sq, err := parseSearchQuery(r)
+	if err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(sq); err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	results, err := app.store.Workouts.Search(ctx, sq)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err = app.jsonResponse(w, http.StatusOK, results); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
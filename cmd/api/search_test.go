@@ -0,0 +1,86 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+var (
+	SearchUrl       = newCollectionPath("search")
+	AutocompleteUrl = newCollectionPath("autocomplete")
+)
+
+func TestSearch(t *testing.T) {
+	mockWorkoutStore := new(mocks.MockWorkoutStore)
+
+	store := store.Storage{
+		Workouts: mockWorkoutStore,
+		Users:    new(mocks.MockUserStore),
+	}
+
+	app := newTestApplication(t, store)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		query              string
+		expectedStatusCode int
+	}{
+		{"should return 200", "?q=tricep+pushdown", http.StatusOK},
+		{"should return 200 - with limit", "?q=squat&limit=5", http.StatusOK},
+		{"should return 400 - missing query", "", http.StatusBadRequest},
+		{"should return 400 - blank query", "?q=%20%20", http.StatusBadRequest},
+		{"should return 400 - limit too large", "?q=squat&limit=51", http.StatusBadRequest},
+		{"should return 400 - non numeric limit", "?q=squat&limit=all", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, SearchUrl+tt.query, nil)
+
+			res := execRequest(mux, authorize(t, app, req, 1))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			assertContentType(t, res.Header().Get("content-type"), contentTypeFor(res.Code))
+		})
+	}
+}
+
+func TestAutocomplete(t *testing.T) {
+	mockAutocompleteStore := new(mocks.MockAutocompleteStore)
+
+	store := store.Storage{
+		Autocomplete: mockAutocompleteStore,
+		Users:        new(mocks.MockUserStore),
+	}
+
+	app := newTestApplication(t, store)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		query              string
+		expectedStatusCode int
+	}{
+		{"should return 200 - all types", "?q=tricep+pushdwn", http.StatusOK},
+		{"should return 200 - selected types", "?q=dumbel&types=equipment,workout&limit=5", http.StatusOK},
+		{"should return 400 - missing query", "?types=workout", http.StatusBadRequest},
+		{"should return 400 - unknown type", "?q=curl&types=workout,exercise", http.StatusBadRequest},
+		{"should return 400 - empty type", "?q=curl&types=workout,", http.StatusBadRequest},
+		{"should return 400 - limit too large", "?q=curl&limit=100", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, AutocompleteUrl+tt.query, nil)
+
+			res := execRequest(mux, authorize(t, app, req, 1))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			assertContentType(t, res.Header().Get("content-type"), contentTypeFor(res.Code))
+		})
+	}
+}
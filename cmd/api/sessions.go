@@ -0,0 +1,321 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/metrics"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/go-chi/chi/v5"
+)
+
+type sessionContextKey string
+
+var sessionCtxKey sessionContextKey = "session"
+
+type CreateSessionPayload struct {
+	RoutineID *int64 `json:"routine_id" validate:"omitnil,gt=0"`
+	Notes     string `json:"notes" validate:"max=500"`
+	// StartedAt backdates a session logged after the fact, it defaults to now
+	StartedAt *time.Time `json:"started_at" validate:"omitnil"`
+}
+
+func (p *CreateSessionPayload) normalize() {
+	p.Notes = strings.TrimSpace(p.Notes)
+}
+
+// CreateSession godoc
+//
+//	@Summary		Starts a session
+//	@Description	Starts a workout session for the current user, optionally following one of their routines
+//	@Tags			sessions
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		CreateSessionPayload	true	"Session payload"
+//	@Success		201		{object}	store.Session
+//	@Failure		400		{object}	Problem
+//	@Failure		401		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/sessions [post]
+func (app *application) createSessionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := getUserFromContext(r)
+
+	payload, err := decodeJSON[CreateSessionPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	session := &store.Session{
+		UserID:    user.ID,
+		RoutineID: payload.RoutineID,
+		Notes:     payload.Notes,
+	}
+
+	if payload.StartedAt != nil {
+		if payload.StartedAt.After(time.Now()) {
+			app.badRequest(w, r, newFieldError("started_at", "past", "must not be in the future"))
+			return
+		}
+		session.StartedAt = payload.StartedAt.Format(time.RFC3339)
+	}
+
+	if payload.RoutineID != nil {
+		routine, err := app.store.Routines.GetByID(ctx, *payload.RoutineID)
+		if err != nil {
+			switch err {
+			case store.ErrNotFound:
+				app.badRequest(w, r, newFieldError("routine_id", "exists", "is not a known routine"))
+			default:
+				app.internalServerError(w, r, err)
+			}
+			return
+		}
+
+		// other users' routines are treated as unknown
+		if routine.UserID != user.ID {
+			app.badRequest(w, r, newFieldError("routine_id", "exists", "is not a known routine"))
+			return
+		}
+	}
+
+	if err := app.store.Sessions.Create(ctx, session); err != nil {
+		app.storeError(w, r, err)
+		return
+	}
+	metrics.SessionsStarted.Inc()
+
+	if err := app.jsonResponse(w, http.StatusCreated, session); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// FetchSessions godoc
+//
+//	@Summary		Fetches session history
+//	@Description	Fetches the current user's sessions with per-session totals, optionally within a date range
+//	@Tags			sessions
+//	@Accept			json
+//	@Produce		json
+//	@Param			from	query		string	false	"Sessions started at or after, RFC 3339 or YYYY-MM-DD"
+//	@Param			to		query		string	false	"Sessions started before, RFC 3339 or YYYY-MM-DD (inclusive day)"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			cursor	query		string	false	"Cursor from a previous page"
+//	@Param			sort	query		string	false	"Sort by id or started_at, prefix with - for descending"
+//	@Success		200		{object}	[]store.Session
+//	@Failure		400		{object}	Problem
+//	@Failure		401		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/sessions [get]
+func (app *application) fetchSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := getUserFromContext(r)
+
+	filter, err := parseSessionFilter(r)
+	if err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	page, err := parsePaginatedQuery(r, store.SessionSorts)
+	if err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(page); err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	sessions, meta, err := app.store.Sessions.GetAllByUser(ctx, user.ID, filter, page)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err = app.paginatedJSONResponse(w, http.StatusOK, sessions, meta); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// GetSession godoc
+//
+//	@Summary		Fetches a session
+//	@Description	Fetches one of the current user's sessions with its sets and totals
+//	@Tags			sessions
+//	@Accept			json
+//	@Produce		json
+//	@Param			sessionId	path		int	true	"Session ID"
+//	@Success		200			{object}	store.Session
+//	@Failure		401			{object}	Problem
+//	@Failure		403			{object}	Problem
+//	@Failure		404			{object}	Problem
+//	@Failure		500			{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/sessions/{sessionId} [get]
+func (app *application) getSessionHandler(w http.ResponseWriter, r *http.Request) {
+	session := getSessionFromContext(r)
+
+	if err := app.jsonResponse(w, http.StatusOK, session); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// LogSetPayload records at least one of reps, duration_seconds or
+// distance_meters.
+type LogSetPayload struct {
+	WorkoutID       int64    `json:"workout_id" validate:"required,gt=0"`
+	Reps            *int     `json:"reps" validate:"required_without_all=DurationSeconds DistanceMeters,omitnil,gte=0,lte=1000"`
+	LoadKg          *float64 `json:"load_kg" validate:"omitnil,gte=0,lte=1000"`
+	RPE             *float64 `json:"rpe" validate:"omitnil,gte=1,lte=10"`
+	DurationSeconds *int     `json:"duration_seconds" validate:"omitnil,gte=1,lte=86400"`
+	DistanceMeters  *float64 `json:"distance_meters" validate:"omitnil,gt=0,lte=1000000"`
+}
+
+// LogSet godoc
+//
+//	@Summary		Logs a set
+//	@Description	Logs a set against a workout in an unfinished session, sets are numbered per workout
+//	@Tags			sessions
+//	@Accept			json
+//	@Produce		json
+//	@Param			sessionId	path		int				true	"Session ID"
+//	@Param			payload		body		LogSetPayload	true	"Set payload"
+//	@Success		201			{object}	store.SessionSet
+//	@Failure		400			{object}	Problem
+//	@Failure		401			{object}	Problem
+//	@Failure		403			{object}	Problem
+//	@Failure		404			{object}	Problem
+//	@Failure		409			{object}	Problem
+//	@Failure		500			{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/sessions/{sessionId}/sets [post]
+func (app *application) logSetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	session := getSessionFromContext(r)
+
+	payload, err := decodeJSON[LogSetPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	if session.FinishedAt != nil {
+		app.conflictError(w, r, store.ErrSessionFinished)
+		return
+	}
+
+	set := &store.SessionSet{
+		SessionID:       session.ID,
+		WorkoutID:       payload.WorkoutID,
+		Reps:            payload.Reps,
+		LoadKg:          payload.LoadKg,
+		RPE:             payload.RPE,
+		DurationSeconds: payload.DurationSeconds,
+		DistanceMeters:  payload.DistanceMeters,
+	}
+
+	if err := app.store.Sessions.AddSet(ctx, set); err != nil {
+		switch err {
+		case store.ErrUnknownWorkout:
+			app.badRequest(w, r, newFieldError("workout_id", "exists", "is not a known workout"))
+		case store.ErrSessionFinished:
+			// finished after the check above
+			app.conflictError(w, r, err)
+		case store.ErrNotFound:
+			app.notFound(w, r)
+		default:
+			app.storeError(w, r, err)
+		}
+		return
+	}
+	metrics.SetsLogged.Inc()
+
+	if err := app.jsonResponse(w, http.StatusCreated, set); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// FinishSession godoc
+//
+//	@Summary		Finishes a session
+//	@Description	Marks one of the current user's sessions as finished, no more sets can be logged afterwards
+//	@Tags			sessions
+//	@Accept			json
+//	@Produce		json
+//	@Param			sessionId	path		int	true	"Session ID"
+//	@Success		200			{object}	store.Session
+//	@Failure		401			{object}	Problem
+//	@Failure		403			{object}	Problem
+//	@Failure		404			{object}	Problem
+//	@Failure		409			{object}	Problem
+//	@Failure		500			{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/sessions/{sessionId}/finish [patch]
+func (app *application) finishSessionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	session := getSessionFromContext(r)
+
+	if err := app.store.Sessions.Finish(ctx, session); err != nil {
+		switch err {
+		case store.ErrSessionFinished:
+			app.conflictError(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, session); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+func (app *application) sessionContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id := chi.URLParam(r, "sessionId")
+
+		intId, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			app.badRequest(w, r, errors.New("invalid session id"))
+			return
+		}
+
+		session, err := app.store.Sessions.GetByID(ctx, intId)
+		if err != nil {
+			switch err {
+			case store.ErrNotFound:
+				app.notFound(w, r)
+			default:
+				app.internalServerError(w, r, err)
+			}
+			return
+		}
+
+		// other users' sessions are reported as missing rather than forbidden
+		if session.UserID != getUserFromContext(r).ID {
+			app.notFound(w, r)
+			return
+		}
+
+		ctx = context.WithValue(ctx, sessionCtxKey, session)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func getSessionFromContext(r *http.Request) *store.Session {
+	session, _ := r.Context().Value(sessionCtxKey).(*store.Session)
+	return session
+}
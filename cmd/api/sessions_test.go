@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+var SessionsUrl = newCollectionPath("sessions")
+
+func newSessionTestApplication(t testing.TB) *application {
+	return newTestApplication(t, store.Storage{
+		Users:    new(mocks.MockUserStore),
+		Routines: new(mocks.MockRoutineStore),
+		Sessions: new(mocks.MockSessionStore),
+	})
+}
+
+func TestCreateSession(t *testing.T) {
+	app := newSessionTestApplication(t)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		payload            string
+		expectedStatusCode int
+	}{
+		{"should return 201 - empty session", `{}`, http.StatusCreated},
+		{"should return 201 - own routine", fmt.Sprintf(`{"routine_id": %d, "notes": "leg day"}`, mocks.MockMemberRoutineID), http.StatusCreated},
+		{"should return 201 - backdated", `{"started_at": "2026-01-01T06:30:00Z"}`, http.StatusCreated},
+		{"should return 400 - other user's routine", fmt.Sprintf(`{"routine_id": %d}`, mocks.MockAdminRoutineID), http.StatusBadRequest},
+		{"should return 400 - missing routine", `{"routine_id": 99}`, http.StatusBadRequest},
+		{"should return 400 - future start", `{"started_at": "3000-01-01T00:00:00Z"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(http.MethodPost, SessionsUrl, bytes.NewBufferString(tt.payload))
+
+			res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+		})
+	}
+}
+
+func TestLogSet(t *testing.T) {
+	app := newSessionTestApplication(t)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		sessionID          int64
+		payload            string
+		expectedStatusCode int
+	}{
+		{"should return 201 - reps and load", mocks.MockOpenSessionID, `{"workout_id": 1, "reps": 5, "load_kg": 100, "rpe": 8.5}`, http.StatusCreated},
+		{"should return 201 - timed", mocks.MockOpenSessionID, `{"workout_id": 1, "duration_seconds": 60}`, http.StatusCreated},
+		{"should return 201 - distance", mocks.MockOpenSessionID, `{"workout_id": 1, "distance_meters": 5000}`, http.StatusCreated},
+		{"should return 400 - nothing recorded", mocks.MockOpenSessionID, `{"workout_id": 1, "load_kg": 100}`, http.StatusBadRequest},
+		{"should return 400 - rpe out of range", mocks.MockOpenSessionID, `{"workout_id": 1, "reps": 5, "rpe": 11}`, http.StatusBadRequest},
+		{"should return 400 - unknown workout", mocks.MockOpenSessionID, fmt.Sprintf(`{"workout_id": %d, "reps": 5}`, mocks.MockMissingWorkoutID), http.StatusBadRequest},
+		{"should return 409 - finished session", mocks.MockFinishedSessionID, `{"workout_id": 1, "reps": 5}`, http.StatusConflict},
+		{"should return 409 - session finished while logging", mocks.MockFinishingSessionID, `{"workout_id": 1, "reps": 5}`, http.StatusConflict},
+		{"should return 404 - other user's session", mocks.MockAdminSessionID, `{"workout_id": 1, "reps": 5}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(http.MethodPost, fmt.Sprintf("%s/%d/sets", SessionsUrl, tt.sessionID), bytes.NewBufferString(tt.payload))
+
+			res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+		})
+	}
+}
+
+func TestFinishSession(t *testing.T) {
+	app := newSessionTestApplication(t)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		sessionID          int64
+		expectedStatusCode int
+	}{
+		{"should return 200 - open session", mocks.MockOpenSessionID, http.StatusOK},
+		{"should return 409 - already finished", mocks.MockFinishedSessionID, http.StatusConflict},
+		{"should return 404 - other user's session", mocks.MockAdminSessionID, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/%d/finish", SessionsUrl, tt.sessionID), nil)
+
+			res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+		})
+	}
+}
+
+func TestFetchSessions(t *testing.T) {
+	app := newSessionTestApplication(t)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		query              string
+		expectedStatusCode int
+	}{
+		{"should return 200 - all history", "", http.StatusOK},
+		{"should return 200 - date range", "?from=2026-01-01&to=2026-01-31&sort=-started_at", http.StatusOK},
+		{"should return 400 - reversed range", "?from=2026-01-31&to=2026-01-01", http.StatusBadRequest},
+		{"should return 400 - invalid date", "?from=yesterday", http.StatusBadRequest},
+		{"should return 400 - unknown sort", "?sort=volume", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, SessionsUrl+tt.query, nil)
+
+			res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+		})
+	}
+
+	t.Run("should return 200 - only own sessions with totals", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, SessionsUrl, nil)
+
+		res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+		var body struct {
+			Data []store.Session `json:"data"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(body.Data) != 2 {
+			t.Fatalf("got %d sessions want 2", len(body.Data))
+		}
+
+		for _, session := range body.Data {
+			if session.UserID != mocks.MockMemberID {
+				t.Errorf("got session of user %d", session.UserID)
+			}
+			if session.Totals.VolumeKg != 500 {
+				t.Errorf("got volume %v want 500", session.Totals.VolumeKg)
+			}
+		}
+	})
+}
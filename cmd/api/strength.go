@@ -0,0 +1,201 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/strength"
+	"github.com/JerryLegend254/mfit_api/internal/units"
+)
+
+type EstimateSetPayload struct {
+	Load float64  `json:"load" validate:"gt=0,lte=2000"`
+	Reps int      `json:"reps" validate:"gte=1,lte=12"`
+	RPE  *float64 `json:"rpe" validate:"omitnil,gte=1,lte=10"`
+}
+
+// StrengthEstimatePayload takes loads and bodyweight in unit, kilograms by
+// default. The lift is only classified when bodyweight and sex are given.
+type StrengthEstimatePayload struct {
+	Sets       []EstimateSetPayload `json:"sets" validate:"required,min=1,max=20,dive"`
+	Bodyweight *float64             `json:"bodyweight" validate:"omitnil,gt=0,lte=1000"`
+	Sex        *string              `json:"sex" validate:"omitnil,oneof=female male"`
+	Unit       string               `json:"unit" validate:"omitempty,oneof=kg lb"`
+	Increment  *float64             `json:"increment" validate:"omitnil,gt=0,lte=50"`
+}
+
+type SetEstimate struct {
+	Load      float64            `json:"load"`
+	Reps      int                `json:"reps"`
+	RPE       *float64           `json:"rpe"`
+	OneRepMax float64            `json:"one_rep_max"`
+	Formulas  map[string]float64 `json:"formulas"`
+}
+
+// StrengthEstimateResponse reports the best set's estimates. All loads are
+// in unit.
+type StrengthEstimateResponse struct {
+	WorkoutID      int64                    `json:"workout_id"`
+	Unit           string                   `json:"unit"`
+	OneRepMax      float64                  `json:"one_rep_max"`
+	Formulas       map[string]float64       `json:"formulas"`
+	Sets           []SetEstimate            `json:"sets"`
+	Increment      float64                  `json:"increment"`
+	LoadTable      []strength.Load          `json:"load_table"`
+	Classification *strength.Classification `json:"classification"`
+}
+
+// EstimateStrength godoc
+//
+//	@Summary		Estimates a one-rep max
+//	@Description	Estimates a workout's 1RM from performed sets with the Epley, Brzycki, Lombardi and RPE formulas, along with a 50-100% load table and a strength level when the workout has standards
+//	@Tags			workouts
+//	@Accept			json
+//	@Produce		json
+//	@Param			workoutId	path		int						true	"Workout ID"
+//	@Param			payload		body		StrengthEstimatePayload	true	"Performed sets"
+//	@Success		200			{object}	StrengthEstimateResponse
+//	@Failure		400			{object}	Problem
+//	@Failure		404			{object}	Problem
+//	@Failure		500			{object}	Problem
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/workouts/{workoutId}/strength-estimate [post]
+func (app *application) estimateStrengthHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workout := getWorkoutFromContext(r)
+
+	payload, err := decodeJSON[StrengthEstimatePayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	if payload.Unit == "" {
+		payload.Unit = units.Kilogram
+	}
+
+	res := StrengthEstimateResponse{
+		WorkoutID: workout.ID,
+		Unit:      payload.Unit,
+		Increment: app.config.strength.increment(payload.Unit),
+	}
+	if payload.Increment != nil {
+		res.Increment = *payload.Increment
+	}
+
+	var best float64
+	for _, p := range payload.Sets {
+		set := strength.Set{Load: p.Load, Reps: p.Reps}
+		if p.RPE != nil {
+			set.RPE = *p.RPE
+		}
+
+		estimates, err := strength.Estimate(set)
+		if err != nil {
+			app.badRequest(w, r, err)
+			return
+		}
+
+		oneRepMax := strength.Mean(estimates)
+		for formula, e := range estimates {
+			estimates[formula] = units.Round(e, 1)
+		}
+
+		res.Sets = append(res.Sets, SetEstimate{
+			Load:      p.Load,
+			Reps:      p.Reps,
+			RPE:       p.RPE,
+			OneRepMax: units.Round(oneRepMax, 1),
+			Formulas:  estimates,
+		})
+
+		if oneRepMax > best {
+			best = oneRepMax
+			res.OneRepMax = units.Round(oneRepMax, 1)
+			res.Formulas = estimates
+		}
+	}
+
+	res.LoadTable = strength.LoadTable(best, res.Increment)
+
+	if payload.Bodyweight != nil && payload.Sex != nil {
+		standard, err := app.store.StrengthStandards.Get(ctx, workout.ID, *payload.Sex)
+		switch err {
+		case nil:
+			standards := strength.Standards{standard.Beginner, standard.Novice, standard.Intermediate, standard.Advanced, standard.Elite}
+			c := strength.Classify(best, *payload.Bodyweight, standards)
+			c.Ratio = units.Round(c.Ratio, 2)
+			if c.ToNextLevel != nil {
+				*c.ToNextLevel = units.Round(*c.ToNextLevel, 1)
+			}
+			res.Classification = &c
+		case store.ErrNotFound:
+			// the workout has no standards to classify against
+		default:
+			app.internalServerError(w, r, err)
+			return
+		}
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, res); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+type UpdateStrengthStandardPayload struct {
+	Sex          string  `json:"sex" validate:"required,oneof=female male"`
+	Beginner     float64 `json:"beginner" validate:"gt=0,lte=10"`
+	Novice       float64 `json:"novice" validate:"gtfield=Beginner,lte=10"`
+	Intermediate float64 `json:"intermediate" validate:"gtfield=Novice,lte=10"`
+	Advanced     float64 `json:"advanced" validate:"gtfield=Intermediate,lte=10"`
+	Elite        float64 `json:"elite" validate:"gtfield=Advanced,lte=10"`
+}
+
+// UpdateStrengthStandard godoc
+//
+//	@Summary		Sets a workout's strength standards
+//	@Description	Creates or replaces the 1RM to bodyweight ratios for each strength level of a workout and sex
+//	@Tags			workouts
+//	@Accept			json
+//	@Produce		json
+//	@Param			workoutId	path		int								true	"Workout ID"
+//	@Param			payload		body		UpdateStrengthStandardPayload	true	"Strength standard payload"
+//	@Success		200			{object}	store.StrengthStandard
+//	@Failure		400			{object}	Problem
+//	@Failure		401			{object}	Problem
+//	@Failure		403			{object}	Problem
+//	@Failure		404			{object}	Problem
+//	@Failure		500			{object}	Problem
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/workouts/{workoutId}/strength-standards [put]
+func (app *application) updateStrengthStandardHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workout := getWorkoutFromContext(r)
+
+	payload, err := decodeJSON[UpdateStrengthStandardPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	standard := &store.StrengthStandard{
+		WorkoutID:    workout.ID,
+		Sex:          payload.Sex,
+		Beginner:     payload.Beginner,
+		Novice:       payload.Novice,
+		Intermediate: payload.Intermediate,
+		Advanced:     payload.Advanced,
+		Elite:        payload.Elite,
+	}
+
+	if err := app.store.StrengthStandards.Upsert(ctx, standard); err != nil {
+		app.storeError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, standard); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
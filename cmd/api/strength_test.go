@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+func newStrengthEstimateRequest(t testing.TB, workoutID string, payload string) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, WorkoutUrl+"/"+workoutID+"/strength-estimate", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", jsonContentType)
+	return req
+}
+
+func TestEstimateStrength(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		Workouts:          new(mocks.MockWorkoutStore),
+		StrengthStandards: new(mocks.MockStrengthStandardStore),
+	})
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		workoutID          string
+		payload            string
+		expectedStatusCode int
+		expectedOneRepMax  float64
+		expectedLevel      string
+		expectedUnit       string
+		expectedIncrement  float64
+	}{
+		// mean of Epley 116.67, Brzycki 112.5 and Lombardi 117.46
+		{"should return 200 - classified", "1", `{"sets": [{"load": 100, "reps": 5}], "bodyweight": 80, "sex": "male"}`, http.StatusOK, 115.5, "intermediate", "kg", 2.5},
+		{"should return 200 - best set wins", "1", `{"sets": [{"load": 80, "reps": 8}, {"load": 100, "reps": 5}]}`, http.StatusOK, 115.5, "", "kg", 2.5},
+		{"should return 200 - no standards for workout", "2", `{"sets": [{"load": 100, "reps": 5}], "bodyweight": 80, "sex": "male"}`, http.StatusOK, 115.5, "", "kg", 2.5},
+		{"should return 200 - no standards for sex", "1", `{"sets": [{"load": 100, "reps": 5}], "bodyweight": 80, "sex": "female"}`, http.StatusOK, 115.5, "", "kg", 2.5},
+		{"should return 200 - pounds", "1", `{"sets": [{"load": 225, "reps": 1}], "unit": "lb"}`, http.StatusOK, 225, "", "lb", 5},
+		{"should return 200 - custom increment", "1", `{"sets": [{"load": 100, "reps": 1}], "increment": 1.25}`, http.StatusOK, 100, "", "kg", 1.25},
+		{"should return 400 - too many reps", "1", `{"sets": [{"load": 60, "reps": 15}]}`, http.StatusBadRequest, 0, "", "", 0},
+		{"should return 400 - rpe out of range", "1", `{"sets": [{"load": 60, "reps": 5, "rpe": 12}]}`, http.StatusBadRequest, 0, "", "", 0},
+		{"should return 400 - no sets", "1", `{"sets": []}`, http.StatusBadRequest, 0, "", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := execRequest(mux, newStrengthEstimateRequest(t, tt.workoutID, tt.payload))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			if tt.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Data StrengthEstimateResponse `json:"data"`
+			}
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			if body.Data.OneRepMax != tt.expectedOneRepMax {
+				t.Errorf("got 1RM %v want %v", body.Data.OneRepMax, tt.expectedOneRepMax)
+			}
+
+			if body.Data.Unit != tt.expectedUnit || body.Data.Increment != tt.expectedIncrement {
+				t.Errorf("got %v %s increments want %v %s", body.Data.Increment, body.Data.Unit, tt.expectedIncrement, tt.expectedUnit)
+			}
+
+			level := ""
+			if body.Data.Classification != nil {
+				level = body.Data.Classification.Level
+			}
+			if level != tt.expectedLevel {
+				t.Errorf("got level %q want %q", level, tt.expectedLevel)
+			}
+
+			if len(body.Data.LoadTable) != 11 {
+				t.Fatalf("got %d load table rows want 11", len(body.Data.LoadTable))
+			}
+			for _, row := range body.Data.LoadTable {
+				if steps := row.Load / tt.expectedIncrement; math.Abs(steps-math.Round(steps)) > 1e-9 {
+					t.Errorf("%d%%: %v is not a multiple of %v", row.Percent, row.Load, tt.expectedIncrement)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateStrengthStandard(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		Workouts:          new(mocks.MockWorkoutStore),
+		Users:             new(mocks.MockUserStore),
+		StrengthStandards: new(mocks.MockStrengthStandardStore),
+	})
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		userID             int64
+		payload            string
+		expectedStatusCode int
+	}{
+		{"should return 200 - admin", mocks.MockAdminID, `{"sex": "female", "beginner": 0.25, "novice": 0.5, "intermediate": 0.75, "advanced": 1, "elite": 1.5}`, http.StatusOK},
+		{"should return 400 - levels out of order", mocks.MockAdminID, `{"sex": "female", "beginner": 0.25, "novice": 0.5, "intermediate": 0.4, "advanced": 1, "elite": 1.5}`, http.StatusBadRequest},
+		{"should return 400 - unknown sex", mocks.MockAdminID, `{"sex": "other", "beginner": 0.25, "novice": 0.5, "intermediate": 0.75, "advanced": 1, "elite": 1.5}`, http.StatusBadRequest},
+		{"should return 403 - member", mocks.MockMemberID, `{"sex": "female", "beginner": 0.25, "novice": 0.5, "intermediate": 0.75, "advanced": 1, "elite": 1.5}`, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(http.MethodPut, WorkoutUrl+"/1/strength-standards", bytes.NewBufferString(tt.payload))
+
+			res := execRequest(mux, authorize(t, app, req, tt.userID))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+		})
+	}
+}
@@ -2,10 +2,10 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/JerryLegend254/mfit_api/internal/store"
 	"github.com/go-chi/chi/v5"
@@ -20,6 +20,10 @@ type CreateTargetPayload struct {
 	BodyPartID int64  `json:"bodypart_id" validate:"required"`
 }
 
+func (p *CreateTargetPayload) normalize() {
+	p.Name = strings.TrimSpace(p.Name)
+}
+
 // CreateTarget godoc
 //
 //	@Summary		Creates a target
@@ -29,23 +33,19 @@ type CreateTargetPayload struct {
 //	@Produce		json
 //	@Param			payload	body		CreateTargetPayload	true	"Target payload"
 //	@Success		201		{object}	store.Target
-//	@Failure		400		{object}	error
-//	@Failure		403		{object}	error
-//	@Failure		500		{object}	error
+//	@Failure		400		{object}	Problem
+//	@Failure		409		{object}	Problem
+//	@Failure		422		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		500		{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/targets [post]
 func (app *application) createTargetHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	var payload CreateTargetPayload
-
-	err := json.NewDecoder(r.Body).Decode(&payload)
+	payload, err := decodeJSON[CreateTargetPayload](w, r)
 	if err != nil {
-		app.badRequest(w, r, err)
-		return
-	}
-
-	if err := Validate.Struct(payload); err != nil {
-		app.badRequest(w, r, err)
+		app.decodeError(w, r, err)
 		return
 	}
 
@@ -55,12 +55,7 @@ func (app *application) createTargetHandler(w http.ResponseWriter, r *http.Reque
 	}
 
 	if err = app.store.Targets.Create(ctx, &target); err != nil {
-		switch err {
-		case store.ErrDuplicate:
-			app.conflictError(w, r, store.ErrDuplicateName)
-		default:
-			app.internalServerError(w, r, err)
-		}
+		app.storeError(w, r, err)
 		return
 	}
 
@@ -72,25 +67,53 @@ func (app *application) createTargetHandler(w http.ResponseWriter, r *http.Reque
 // GetAllTargets godoc
 //
 //	@Summary		Fetch all target
-//	@Description	Fetch all target
+//	@Description	Fetch all target, optionally filtered by body part
 //	@Tags			targets
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	[]store.Target
-//	@Failure		403	{object}	error
-//	@Failure		500	{object}	error
+//	@Param			bodypart_id	query		int		false	"Body Part ID"
+//	@Param			limit		query		int		false	"Page size (1-100)"	default(20)
+//	@Param			cursor		query		string	false	"Opaque cursor from a previous page"
+//	@Param			sort		query		string	false	"Sort field, prefix with - for descending"	Enums(id, -id, name, -name, body_part, -body_part)
+//	@Success		200			{object}	[]store.PresentableTarget
+//	@Failure		400			{object}	Problem
+//	@Failure		403			{object}	Problem
+//	@Failure		500			{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/targets [get]
 func (app *application) fetchTargetsHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	targets, err := app.store.Targets.GetAll(ctx)
+	filter, err := parseTargetFilter(r)
+	if err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(filter); err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	page, err := parsePaginatedQuery(r, store.TargetSorts)
+	if err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(page); err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	targets, meta, err := app.store.Targets.GetAll(ctx, filter, page)
 	if err != nil {
 		app.internalServerError(w, r, err)
 		return
 	}
 
-	if err = app.jsonResponse(w, http.StatusOK, targets); err != nil {
+	if err = app.paginatedJSONResponse(w, http.StatusOK, targets, meta); err != nil {
 		app.internalServerError(w, r, err)
 	}
 }
@@ -104,9 +127,10 @@ func (app *application) fetchTargetsHandler(w http.ResponseWriter, r *http.Reque
 //	@Produce		json
 //	@Param			targetId	path		int	true	"Target ID"
 //	@Success		200			{object}	store.Target
-//	@Failure		404			{object}	error
-//	@Failure		500			{object}	error
+//	@Failure		404			{object}	Problem
+//	@Failure		500			{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/targets/{targetId} [get]
 func (app *application) getTargetHandler(w http.ResponseWriter, r *http.Request) {
 	target := getTargetFromContext(r)
@@ -123,13 +147,17 @@ func (app *application) getTargetHandler(w http.ResponseWriter, r *http.Request)
 //	@Tags			targets
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path	int	true	"Target ID"
+//	@Param			id			path	int		true	"Target ID"
+//	@Param			X-TOTP-Code	header	string	true	"Current TOTP code or an unused recovery code"
 //	@Success		204
-//	@Failure		400	{object}	error
-//	@Failure		401	{object}	error
-//	@Failure		404	{object}	error
-//	@Failure		500	{object}	error
+//	@Failure		400	{object}	Problem
+//	@Failure		401	{object}	Problem
+//	@Failure		403	{object}	Problem
+//	@Failure		404	{object}	Problem
+//	@Failure		429	{object}	Problem
+//	@Failure		500	{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/targets/{targetId} [delete]
 func (app *application) deleteTargetHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -153,6 +181,10 @@ type UpdateTargetPayload struct {
 	BodyPartID *int64  `json:"bodypart_id" validate:"omitempty"`
 }
 
+func (p *UpdateTargetPayload) normalize() {
+	trimString(p.Name)
+}
+
 // UddateTarget godoc
 //
 //	@Summary		Update a target
@@ -163,26 +195,23 @@ type UpdateTargetPayload struct {
 //	@Param			targetId	path		int					true	"Target ID"
 //	@Param			targetId	body		UpdateTargetPayload	true	"Target ID"
 //	@Success		200			{object}	store.Target
-//	@Failure		400			{object}	error
-//	@Failure		401			{object}	error
-//	@Failure		404			{object}	error
-//	@Failure		500			{object}	error
+//	@Failure		400			{object}	Problem
+//	@Failure		401			{object}	Problem
+//	@Failure		404			{object}	Problem
+//	@Failure		409			{object}	Problem
+//	@Failure		422			{object}	Problem
+//	@Failure		500			{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/targets/{targetId} [patch]
 func (app *application) updateTargetHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	target := getTargetFromContext(r)
 
-	var payload UpdateTargetPayload
-
-	if err := readJSON(w, r, &payload); err != nil {
-		app.badRequest(w, r, err)
-		return
-	}
-
-	if err := Validate.Struct(payload); err != nil {
-		app.badRequest(w, r, err)
+	payload, err := decodeJSON[UpdateTargetPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
 		return
 	}
 
@@ -195,7 +224,7 @@ func (app *application) updateTargetHandler(w http.ResponseWriter, r *http.Reque
 	}
 
 	if err := app.store.Targets.Update(ctx, target); err != nil {
-		app.internalServerError(w, r, err)
+		app.storeError(w, r, err)
 		return
 	}
 
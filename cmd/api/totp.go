@@ -0,0 +1,281 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/auth"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+const (
+	totpHeader        = "X-TOTP-Code"
+	recoveryCodeCount = 10
+
+	// six digit codes stay out of reach of guessing at five tries per
+	// quarter hour
+	maxStepUpFailures = 5
+	stepUpLockout     = 15 * time.Minute
+)
+
+type TOTPEnrollmentResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+	// QRCode is a base64 encoded PNG of URI
+	QRCode string `json:"qr_png"`
+}
+
+// EnrollTOTP godoc
+//
+//	@Summary		Starts TOTP enrollment
+//	@Description	Generates a TOTP secret for the current user. Scan the QR code or URI with an authenticator app, then confirm with a code
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	TOTPEnrollmentResponse
+//	@Failure		401	{object}	Problem
+//	@Failure		403	{object}	Problem
+//	@Failure		409	{object}	Problem
+//	@Failure		500	{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/auth/totp/enroll [post]
+func (app *application) enrollTOTPHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUserFromContext(r)
+	if user == nil {
+		app.forbiddenError(w, r, "totp enrollment requires a user account")
+		return
+	}
+
+	enrollment, err := auth.GenerateTOTP(app.config.auth.totp.issuer, user.Email)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.store.TOTP.Enroll(ctx, &store.TOTP{UserID: user.ID, Secret: enrollment.Secret}); err != nil {
+		switch err {
+		case store.ErrTOTPEnrolled:
+			app.conflictError(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	res := TOTPEnrollmentResponse{
+		Secret: enrollment.Secret,
+		URI:    enrollment.URI,
+		QRCode: base64.StdEncoding.EncodeToString(enrollment.QRCode),
+	}
+
+	if err := app.jsonResponse(w, http.StatusCreated, res); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+type ConfirmTOTPPayload struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// ConfirmTOTP godoc
+//
+//	@Summary		Confirms TOTP enrollment
+//	@Description	Confirms a pending enrollment with a current code and returns single-use recovery codes, which are only shown once
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body		ConfirmTOTPPayload	true	"Current TOTP code"
+//	@Success		200		{object}	RecoveryCodesResponse
+//	@Failure		400		{object}	Problem
+//	@Failure		401		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		409		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/auth/totp/confirm [post]
+func (app *application) confirmTOTPHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user := getUserFromContext(r)
+	if user == nil {
+		app.forbiddenError(w, r, "totp enrollment requires a user account")
+		return
+	}
+
+	payload, err := decodeJSON[ConfirmTOTPPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	t, err := app.store.TOTP.Get(ctx, user.ID)
+	if err != nil {
+		switch err {
+		case store.ErrNotFound:
+			app.badRequest(w, r, errors.New("no pending totp enrollment"))
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if t.ConfirmedAt != nil {
+		app.conflictError(w, r, store.ErrTOTPEnrolled)
+		return
+	}
+
+	step, ok := auth.ValidateTOTP(t.Secret, payload.Code, time.Now())
+	if !ok {
+		app.badRequest(w, r, errors.New("invalid totp code"))
+		return
+	}
+
+	codes, hashes, err := auth.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.store.TOTP.Confirm(ctx, user.ID, step, hashes); err != nil {
+		switch err {
+		case store.ErrNotFound:
+			app.conflictError(w, r, store.ErrTOTPEnrolled)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes}); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// DeleteTOTP godoc
+//
+//	@Summary		Removes TOTP enrollment
+//	@Description	Removes the current user's TOTP secret and recovery codes
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-TOTP-Code	header	string	true	"Current TOTP code or an unused recovery code"
+//	@Success		204
+//	@Failure		401	{object}	Problem
+//	@Failure		403	{object}	Problem
+//	@Failure		404	{object}	Problem
+//	@Failure		429	{object}	Problem
+//	@Failure		500	{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/auth/totp [delete]
+func (app *application) deleteTOTPHandler(w http.ResponseWriter, r *http.Request) {
+	user := getUserFromContext(r)
+
+	if err := app.store.TOTP.Delete(r.Context(), user.ID); err != nil {
+		switch err {
+		case store.ErrNotFound:
+			app.notFound(w, r)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requireStepUp guards destructive operations behind a second factor: the
+// caller must be a user with a confirmed TOTP enrollment and send a current
+// code, or an unused recovery code, in the X-TOTP-Code header. Each code is
+// accepted once and is consumed before the operation runs, so an operation
+// that then fails needs a fresh code; consuming it afterwards would let a
+// concurrent request replay it. Every attempt is counted before its code is
+// checked and only an accepted code clears the count, after
+// maxStepUpFailures attempts in a row step-up is locked for stepUpLockout.
+// It must run after AuthTokenMiddleware.
+func (app *application) requireStepUp(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		user := getUserFromContext(r)
+		if user == nil {
+			app.forbiddenError(w, r, "this operation requires a user account with totp")
+			return
+		}
+
+		code := r.Header.Get(totpHeader)
+		if code == "" {
+			app.forbiddenError(w, r, "this operation requires a totp code in the "+totpHeader+" header")
+			return
+		}
+
+		t, err := app.store.TOTP.Get(ctx, user.ID)
+		if err != nil && err != store.ErrNotFound {
+			app.internalServerError(w, r, err)
+			return
+		}
+
+		if t == nil || t.ConfirmedAt == nil {
+			app.forbiddenError(w, r, "this operation requires totp enrollment")
+			return
+		}
+
+		if lockedUntil, err := app.store.TOTP.ClaimAttempt(ctx, user.ID, maxStepUpFailures, stepUpLockout); err != nil {
+			switch err {
+			case store.ErrTOTPLocked:
+				app.tooManyRequests(w, r, "too many invalid totp codes", time.Until(lockedUntil))
+			case store.ErrNotFound:
+				app.forbiddenError(w, r, "this operation requires totp enrollment")
+			default:
+				app.internalServerError(w, r, err)
+			}
+			return
+		}
+
+		if step, ok := auth.ValidateTOTP(t.Secret, code, time.Now()); ok {
+			if err := app.store.TOTP.UseStep(ctx, user.ID, step); err != nil {
+				switch err {
+				case store.ErrTOTPReplay:
+					app.forbiddenError(w, r, "totp code was already used")
+				default:
+					app.internalServerError(w, r, err)
+				}
+				return
+			}
+
+			app.stepUpSucceeded(w, r, user.ID, next)
+			return
+		}
+
+		if err := app.store.TOTP.UseRecoveryCode(ctx, user.ID, auth.HashRecoveryCode(code)); err != nil {
+			switch err {
+			case store.ErrNotFound:
+				app.forbiddenError(w, r, "invalid totp code")
+			default:
+				app.internalServerError(w, r, err)
+			}
+			return
+		}
+
+		app.requestLogger(r).Infow("recovery code used for step-up", "user_id", user.ID)
+		app.stepUpSucceeded(w, r, user.ID, next)
+	})
+}
+
+// stepUpSucceeded clears the attempts counted against the user before
+// running the guarded operation.
+func (app *application) stepUpSucceeded(w http.ResponseWriter, r *http.Request, userID int64, next http.Handler) {
+	if err := app.store.TOTP.ResetFailures(r.Context(), userID); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	next.ServeHTTP(w, r)
+}
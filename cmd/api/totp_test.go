@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+	"github.com/pquerna/otp/totp"
+)
+
+var (
+	EnrollTOTPUrl  = newCollectionPath("auth/totp/enroll")
+	ConfirmTOTPUrl = newCollectionPath("auth/totp/confirm")
+)
+
+func TestEnrollTOTP(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		Users: new(mocks.MockUserStore),
+		TOTP:  new(mocks.MockTOTPStore),
+	})
+	mux := app.mount()
+
+	t.Run("should return 201 - pending enrollment", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, EnrollTOTPUrl, nil)
+
+		res := execRequest(mux, authorize(t, app, req, mocks.MockMemberID))
+
+		assertStatusCode(t, res.Code, http.StatusCreated)
+
+		var body struct {
+			Data TOTPEnrollmentResponse `json:"data"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.HasPrefix(body.Data.URI, "otpauth://totp/") || !strings.Contains(body.Data.URI, body.Data.Secret) {
+			t.Errorf("unexpected otpauth uri %q", body.Data.URI)
+		}
+
+		if body.Data.QRCode == "" {
+			t.Error("expected a qr code")
+		}
+	})
+
+	t.Run("should return 409 - already enrolled", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, EnrollTOTPUrl, nil)
+
+		res := execRequest(mux, authorize(t, app, req, mocks.MockAdminID))
+
+		assertStatusCode(t, res.Code, http.StatusConflict)
+	})
+
+	t.Run("should return 401 - anonymous", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, EnrollTOTPUrl, nil)
+
+		res := execRequest(mux, req)
+
+		assertStatusCode(t, res.Code, http.StatusUnauthorized)
+	})
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		Users: new(mocks.MockUserStore),
+		TOTP:  new(mocks.MockTOTPStore),
+	})
+	mux := app.mount()
+
+	code, _ := totp.GenerateCode(mocks.MockTOTPSecret, time.Now())
+
+	tests := []struct {
+		name               string
+		userID             int64
+		payload            string
+		expectedStatusCode int
+	}{
+		{"should return 400 - malformed code", mocks.MockMemberID, `{"code": "12ab56"}`, http.StatusBadRequest},
+		{"should return 400 - wrong code", mocks.MockMemberID, `{"code": "000000"}`, http.StatusBadRequest},
+		{"should return 409 - already confirmed", mocks.MockAdminID, `{"code": "` + code + `"}`, http.StatusConflict},
+		{"should return 200", mocks.MockMemberID, `{"code": "` + code + `"}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(http.MethodPost, ConfirmTOTPUrl, strings.NewReader(tt.payload))
+
+			res := execRequest(mux, authorize(t, app, req, tt.userID))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			if tt.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Data RecoveryCodesResponse `json:"data"`
+			}
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(body.Data.RecoveryCodes) != recoveryCodeCount {
+				t.Errorf("got %d recovery codes want %d", len(body.Data.RecoveryCodes), recoveryCodeCount)
+			}
+		})
+	}
+}
+
+func TestRequireStepUp(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		Workouts: new(mocks.MockWorkoutStore),
+		Users:    new(mocks.MockUserStore),
+		TOTP:     new(mocks.MockTOTPStore),
+	})
+	mux := app.mount()
+
+	code, _ := totp.GenerateCode(mocks.MockTOTPSecret, time.Now())
+
+	// subtests share the store, so order matters for the single-use checks
+	tests := []struct {
+		name               string
+		code               string
+		expectedStatusCode int
+		expectedReason     string
+	}{
+		{"should return 403 - missing code", "", http.StatusForbidden, "X-TOTP-Code"},
+		{"should return 403 - wrong code", "000000", http.StatusForbidden, "invalid totp code"},
+		{"should return 204 - valid code", code, http.StatusNoContent, ""},
+		{"should return 403 - replayed code", code, http.StatusForbidden, "already used"},
+		{"should return 204 - recovery code", strings.ToUpper(mocks.MockRecoveryCode), http.StatusNoContent, ""},
+		{"should return 403 - reused recovery code", mocks.MockRecoveryCode, http.StatusForbidden, "invalid totp code"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newDeleteWorkoutRequest(1)
+			if tt.code != "" {
+				req.Header.Set(totpHeader, tt.code)
+			}
+
+			res := execRequest(mux, authorize(t, app, req, mocks.MockAdminID))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			if !bytes.Contains(res.Body.Bytes(), []byte(tt.expectedReason)) {
+				t.Errorf("expected %q in %s", tt.expectedReason, res.Body)
+			}
+		})
+	}
+}
+
+func TestStepUpLockout(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		Workouts: new(mocks.MockWorkoutStore),
+		Users:    new(mocks.MockUserStore),
+		TOTP:     new(mocks.MockTOTPStore),
+	})
+	mux := app.mount()
+
+	stepUpWith := func(code string) *httptest.ResponseRecorder {
+		req := newDeleteWorkoutRequest(1)
+		req.Header.Set(totpHeader, code)
+		return execRequest(mux, authorize(t, app, req, mocks.MockAdminID))
+	}
+
+	for i := 0; i < maxStepUpFailures; i++ {
+		assertStatusCode(t, stepUpWith("000000").Code, http.StatusForbidden)
+	}
+
+	code, _ := totp.GenerateCode(mocks.MockTOTPSecret, time.Now())
+	res := stepUpWith(code)
+
+	assertStatusCode(t, res.Code, http.StatusTooManyRequests)
+
+	if res.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header")
+	}
+}
+
+func TestStepUpLockoutBurst(t *testing.T) {
+	app := newTestApplication(t, store.Storage{
+		Workouts: new(mocks.MockWorkoutStore),
+		Users:    new(mocks.MockUserStore),
+		TOTP:     new(mocks.MockTOTPStore),
+	})
+	mux := app.mount()
+
+	const burst = 3 * maxStepUpFailures
+
+	var (
+		wg    sync.WaitGroup
+		codes = make(chan int, burst)
+	)
+	for i := 0; i < burst; i++ {
+		req := newDeleteWorkoutRequest(1)
+		req.Header.Set(totpHeader, "000000")
+		req = authorize(t, app, req, mocks.MockAdminID)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes <- execRequest(mux, req).Code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	guesses := 0
+	for code := range codes {
+		if code == http.StatusForbidden {
+			guesses++
+		}
+	}
+
+	if guesses != maxStepUpFailures {
+		t.Errorf("got %d checked guesses want %d", guesses, maxStepUpFailures)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/go-chi/chi/v5"
+)
+
+type UpdateUserRolePayload struct {
+	Role string `json:"role" validate:"required,oneof=member coach admin"`
+}
+
+// UpdateUserRole godoc
+//
+//	@Summary		Update a user's role
+//	@Description	Update a user's role by ID
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			userId	path		int						true	"User ID"
+//	@Param			payload	body		UpdateUserRolePayload	true	"Role payload"
+//	@Success		200		{object}	store.User
+//	@Failure		400		{object}	Problem
+//	@Failure		401		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		404		{object}	Problem
+//	@Failure		500		{object}	Problem
+//	@Security		BearerAuth
+//	@Router			/users/{userId}/role [put]
+func (app *application) updateUserRoleHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, err := strconv.ParseInt(chi.URLParam(r, "userId"), 10, 64)
+	if err != nil {
+		app.badRequest(w, r, errors.New("invalid user id"))
+		return
+	}
+
+	payload, err := decodeJSON[UpdateUserRolePayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	user, err := app.store.Users.GetByID(ctx, userID)
+	if err != nil {
+		switch err {
+		case store.ErrNotFound:
+			app.notFound(w, r)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	user.Role = payload.Role
+
+	if err := app.store.Users.UpdateRole(ctx, user); err != nil {
+		switch err {
+		case store.ErrNotFound:
+			app.notFound(w, r)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, user); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
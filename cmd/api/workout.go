@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 
+	"github.com/JerryLegend254/mfit_api/internal/metrics"
 	"github.com/JerryLegend254/mfit_api/internal/store"
 	"github.com/go-chi/chi/v5"
 )
@@ -28,6 +30,12 @@ type CreateWorkoutPayload struct {
 	SecondaryTargets []int64  `json:"secondary_targets" validate:"required"`
 }
 
+func (p *CreateWorkoutPayload) normalize() {
+	p.Name = strings.TrimSpace(p.Name)
+	p.GifUrl = strings.TrimSpace(p.GifUrl)
+	trimStrings(p.Instructions)
+}
+
 // CreateWorkout godoc
 //
 //	@Summary		Creates a workout
@@ -37,23 +45,19 @@ type CreateWorkoutPayload struct {
 //	@Produce		json
 //	@Param			payload	body		CreateWorkoutPayload	true	"Workout payload"
 //	@Success		201		{object}	store.Workout
-//	@Failure		400		{object}	error
-//	@Failure		403		{object}	error
-//	@Failure		500		{object}	error
+//	@Failure		400		{object}	Problem
+//	@Failure		409		{object}	Problem
+//	@Failure		422		{object}	Problem
+//	@Failure		403		{object}	Problem
+//	@Failure		500		{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/workouts [post]
 func (app *application) createWorkoutHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	var payload CreateWorkoutPayload
-
-	err := json.NewDecoder(r.Body).Decode(&payload)
+	payload, err := decodeJSON[CreateWorkoutPayload](w, r)
 	if err != nil {
-		app.badRequest(w, r, err)
-		return
-	}
-
-	if err := Validate.Struct(payload); err != nil {
-		app.badRequest(w, r, err)
+		app.decodeError(w, r, err)
 		return
 	}
 
@@ -69,14 +73,10 @@ func (app *application) createWorkoutHandler(w http.ResponseWriter, r *http.Requ
 	}
 
 	if err = app.store.Workouts.CreateAndLinkTargets(ctx, &workout, payload.PrimaryTarget, payload.SecondaryTargets); err != nil {
-		switch err {
-		case store.ErrDuplicate:
-			app.conflictError(w, r, store.ErrDuplicateName)
-		default:
-			app.internalServerError(w, r, err)
-		}
+		app.storeError(w, r, err)
 		return
 	}
+	metrics.WorkoutsCreated.Inc()
 
 	if err = app.jsonResponse(w, http.StatusCreated, &workout); err != nil {
 		app.internalServerError(w, r, err)
@@ -86,25 +86,57 @@ func (app *application) createWorkoutHandler(w http.ResponseWriter, r *http.Requ
 // GetAllWorkouts godoc
 //
 //	@Summary		Fetch all workout
-//	@Description	Fetch all workout
+//	@Description	Fetch all workout, optionally filtered by body part, equipment, difficulty and target
 //	@Tags			workouts
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{object}	[]store.PresentableWorkout
-//	@Failure		403	{object}	error
-//	@Failure		500	{object}	error
+//	@Param			bodypart_id		query		int		false	"Body Part ID"
+//	@Param			equipment_id	query		int		false	"Equipment ID"
+//	@Param			difficulty		query		string	false	"Difficulty"	Enums(beginner, intermediate, advanced)
+//	@Param			target_id		query		int		false	"Target ID"
+//	@Param			target_type		query		string	false	"Restrict target_id to primary or secondary links"	Enums(primary, secondary)
+//	@Param			limit			query		int		false	"Page size (1-100)"									default(20)
+//	@Param			cursor			query		string	false	"Opaque cursor from a previous page"
+//	@Param			sort			query		string	false	"Sort field, prefix with - for descending"	Enums(id, -id, name, -name, difficulty, -difficulty, duration_minutes, -duration_minutes, calories_burned, -calories_burned)
+//	@Success		200				{object}	[]store.PresentableWorkout
+//	@Failure		400				{object}	Problem
+//	@Failure		403				{object}	Problem
+//	@Failure		500				{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/workouts [get]
 func (app *application) fetchWorkoutsHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	workouts, err := app.store.Workouts.GetAll(ctx)
+	filter, err := parseWorkoutFilter(r)
+	if err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(filter); err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	page, err := parsePaginatedQuery(r, store.WorkoutSorts)
+	if err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(page); err != nil {
+		app.badRequest(w, r, err)
+		return
+	}
+
+	workouts, meta, err := app.store.Workouts.GetAll(ctx, filter, page)
 	if err != nil {
 		app.internalServerError(w, r, err)
 		return
 	}
 
-	if err = app.jsonResponse(w, http.StatusOK, workouts); err != nil {
+	if err = app.paginatedJSONResponse(w, http.StatusOK, workouts, meta); err != nil {
 		app.internalServerError(w, r, err)
 	}
 }
@@ -118,9 +150,10 @@ func (app *application) fetchWorkoutsHandler(w http.ResponseWriter, r *http.Requ
 //	@Produce		json
 //	@Param			workoutId	path		int	true	"Workout ID"
 //	@Success		200			{object}	store.PresentableWorkout
-//	@Failure		404			{object}	error
-//	@Failure		500			{object}	error
+//	@Failure		404			{object}	Problem
+//	@Failure		500			{object}	Problem
 //	@Security		ApiKeyAuth
+//	@Security		BearerAuth
 //	@Router			/workouts/{workoutId} [get]
 func (app *application) getWorkoutHandler(w http.ResponseWriter, r *http.Request) {
 	_ = r.Context()
@@ -130,97 +163,173 @@ func (app *application) getWorkoutHandler(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// // DeleteWorkout godoc
-// //
-// //	@Summary		Deletes a workout
-// //	@Description	Deletes a workout by ID
-// //	@Tags			workouts
-// //	@Accept			json
-// //	@Produce		json
-// //	@Param			id	path	int	true	"Workout ID"
-// //	@Success		204
-// //	@Failure		400	{object}	error
-// //	@Failure		401	{object}	error
-// //	@Failure		404	{object}	error
-// //	@Failure		500	{object}	error
-// //	@Security		ApiKeyAuth
-// //	@Router			/workouts/{workoutId} [delete]
+// DeleteWorkout godoc
 //
-//	func (app *application) deleteWorkoutHandler(w http.ResponseWriter, r *http.Request) {
-//		ctx := r.Context()
-//		workout := getWorkoutFromContext(r)
-//
-//		if err := app.store.Workouts.Delete(ctx, workout.ID); err != nil {
-//			switch err {
-//			case store.ErrNotFound:
-//				app.notFound(w, r)
-//			default:
-//				app.internalServerError(w, r, err)
-//			}
-//			return
-//		}
-//
-//		w.WriteHeader(http.StatusNoContent)
-//	}
-//
-//	type UpdateWorkoutPayload struct {
-//		Name       *string `json:"name" validate:"omitempty,max=40"`
-//		BodyPartID *int64  `json:"bodypart_id" validate:"omitempty"`
-//	}
-//
-// // UddateWorkout godoc
-// //
-// //	@Summary		Update a workout
-// //	@Description	Update a workout by ID
-// //	@Tags			workouts
-// //	@Accept			json
-// //	@Produce		json
-// //	@Param			workoutId	path		int						true	"Workout ID"
-// //	@Param			workoutId	body		UpdateWorkoutPayload	true	"Workout ID"
-// //	@Success		200			{object}	store.Workout
-// //	@Failure		400			{object}	error
-// //	@Failure		401			{object}	error
-// //	@Failure		404			{object}	error
-// //	@Failure		500			{object}	error
-// //	@Security		ApiKeyAuth
-// //	@Router			/workouts/{workoutId} [patch]
-//
-//	func (app *application) updateWorkoutHandler(w http.ResponseWriter, r *http.Request) {
-//		ctx := r.Context()
-//
-//		workout := getWorkoutFromContext(r)
-//
-//		var payload UpdateWorkoutPayload
-//
-//		if err := readJSON(w, r, &payload); err != nil {
-//			app.badRequest(w, r, err)
-//			return
-//		}
-//
-//		if err := Validate.Struct(payload); err != nil {
-//			app.badRequest(w, r, err)
-//			return
-//		}
-//
-//		if payload.Name != nil {
-//			workout.Name = *payload.Name
-//		}
-//
-//		if payload.BodyPartID != nil {
-//			workout.BodyPartID = *payload.BodyPartID
-//		}
-//
-//		if err := app.store.Workouts.Update(ctx, workout); err != nil {
-//			app.internalServerError(w, r, err)
-//			return
-//		}
-//
-//		if err := app.jsonResponse(w, http.StatusOK, workout); err != nil {
-//			app.internalServerError(w, r, err)
-//			return
-//		}
+//	@Summary		Deletes a workout
+//	@Description	Deletes a workout by ID
+//	@Tags			workouts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path	int		true	"Workout ID"
+//	@Param			X-TOTP-Code	header	string	true	"Current TOTP code or an unused recovery code"
+//	@Success		204
+//	@Failure		400	{object}	Problem
+//	@Failure		401	{object}	Problem
+//	@Failure		403	{object}	Problem
+//	@Failure		404	{object}	Problem
+//	@Failure		429	{object}	Problem
+//	@Failure		500	{object}	Problem
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/workouts/{workoutId} [delete]
+func (app *application) deleteWorkoutHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	workout := getWorkoutFromContext(r)
+
+	if err := app.store.Workouts.Delete(ctx, workout.ID); err != nil {
+		switch err {
+		case store.ErrNotFound:
+			app.notFound(w, r)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type UpdateWorkoutPayload struct {
+	Name             *string   `json:"name" validate:"omitempty,max=40"`
+	BodyPartID       *int64    `json:"bodypart_id" validate:"omitempty,gt=0"`
+	EquipmentID      *int64    `json:"equipment_id" validate:"omitempty,gt=0"`
+	GifUrl           *string   `json:"gif_url" validate:"omitempty,max=255"`
+	Instructions     *[]string `json:"instructions" validate:"omitnil,dive,max=255"`
+	CaloriesBurned   *uint8    `json:"calories_burned"`
+	DurationMinutes  *uint8    `json:"duration_minutes"`
+	Difficulty       *string   `json:"difficulty" validate:"omitempty,oneof=beginner intermediate advanced"`
+	PrimaryTarget    *int64    `json:"primary_target" validate:"omitempty,gt=0"`
+	SecondaryTargets *[]int64  `json:"secondary_targets" validate:"omitnil,unique,dive,gt=0"`
+}
+
+func (p *UpdateWorkoutPayload) normalize() {
+	trimString(p.Name)
+	trimString(p.GifUrl)
+	if p.Instructions != nil {
+		trimStrings(*p.Instructions)
+	}
+}
+
+// UddateWorkout godoc
 //
-// }
+//	@Summary		Update a workout
+//	@Description	Update a workout by ID, replacing its target links when targets are provided
+//	@Tags			workouts
+//	@Accept			json
+//	@Produce		json
+//	@Param			workoutId	path		int						true	"Workout ID"
+//	@Param			workoutId	body		UpdateWorkoutPayload	true	"Workout ID"
+//	@Success		200			{object}	store.PresentableWorkout
+//	@Failure		400			{object}	Problem
+//	@Failure		401			{object}	Problem
+//	@Failure		404			{object}	Problem
+//	@Failure		409			{object}	Problem
+//	@Failure		422			{object}	Problem
+//	@Failure		500			{object}	Problem
+//	@Security		ApiKeyAuth
+//	@Security		BearerAuth
+//	@Router			/workouts/{workoutId} [patch]
+func (app *application) updateWorkoutHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	presentableWorkout := getWorkoutFromContext(r)
+
+	payload, err := decodeJSON[UpdateWorkoutPayload](w, r)
+	if err != nil {
+		app.decodeError(w, r, err)
+		return
+	}
+
+	workout := presentableWorkout.Workout
+	primaryTarget := presentableWorkout.PrimaryTargetID
+	secondaryTargets := presentableWorkout.SecondaryTargetIDs
+
+	if payload.Name != nil {
+		workout.Name = *payload.Name
+	}
+
+	if payload.BodyPartID != nil {
+		workout.BodyPartID = *payload.BodyPartID
+	}
+
+	if payload.EquipmentID != nil {
+		workout.EquipmentID = *payload.EquipmentID
+	}
+
+	if payload.GifUrl != nil {
+		workout.GifUrl = *payload.GifUrl
+	}
+
+	if payload.Instructions != nil {
+		workout.Instructions = *payload.Instructions
+	}
+
+	if payload.CaloriesBurned != nil {
+		workout.CaloriesBurned = *payload.CaloriesBurned
+	}
+
+	if payload.DurationMinutes != nil {
+		workout.DurationMinutes = *payload.DurationMinutes
+	}
+
+	if payload.Difficulty != nil {
+		workout.Difficulty = *payload.Difficulty
+	}
+
+	if payload.PrimaryTarget != nil {
+		primaryTarget = *payload.PrimaryTarget
+	}
+
+	if payload.SecondaryTargets != nil {
+		secondaryTargets = *payload.SecondaryTargets
+	}
+
+	// workouts stored without a primary target link can only be relinked
+	// with a new one
+	if primaryTarget == 0 {
+		app.badRequest(w, r, newFieldError("primary_target", "required", "is required, the workout has no primary target"))
+		return
+	}
+
+	if slices.Contains(secondaryTargets, primaryTarget) {
+		app.badRequest(w, r, errors.New("primary target cannot also be a secondary target"))
+		return
+	}
+
+	if err := app.store.Workouts.UpdateAndLinkTargets(ctx, &workout, primaryTarget, secondaryTargets); err != nil {
+		switch err {
+		case store.ErrNotFound:
+			app.notFound(w, r)
+		default:
+			app.storeError(w, r, err)
+		}
+		return
+	}
+
+	// refetch so that the response reflects the renamed body part, equipment and targets
+	updated, err := app.store.Workouts.GetByID(ctx, workout.ID)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, http.StatusOK, updated); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+}
+
 func (app *application) workoutContextMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+	"github.com/JerryLegend254/mfit_api/internal/store/mocks"
+)
+
+var (
+	WorkoutUrl = newCollectionPath("workouts")
+)
+
+func TestFetchWorkouts(t *testing.T) {
+	mockWorkoutStore := new(mocks.MockWorkoutStore)
+
+	store := store.Storage{
+		Workouts: mockWorkoutStore,
+		Users:    new(mocks.MockUserStore),
+	}
+
+	app := newTestApplication(t, store)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		query              string
+		expectedStatusCode int
+	}{
+		{"should return 200 - no filters", "", http.StatusOK},
+		{"should return 200 - combined filters", "?bodypart_id=1&equipment_id=2&difficulty=beginner", http.StatusOK},
+		{"should return 200 - secondary target", "?target_id=3&target_type=secondary", http.StatusOK},
+		{"should return 400 - non numeric body part", "?bodypart_id=chest", http.StatusBadRequest},
+		{"should return 400 - negative equipment", "?equipment_id=-1", http.StatusBadRequest},
+		{"should return 400 - invalid difficulty", "?difficulty=expert", http.StatusBadRequest},
+		{"should return 400 - invalid target type", "?target_id=3&target_type=tertiary", http.StatusBadRequest},
+		{"should return 400 - target type without target", "?target_type=primary", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, WorkoutUrl+tt.query, nil)
+
+			res := execRequest(mux, authorize(t, app, req, 1))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			assertContentType(t, res.Header().Get("content-type"), contentTypeFor(res.Code))
+		})
+	}
+}
+
+func TestUpdateWorkout(t *testing.T) {
+	mockWorkoutStore := new(mocks.MockWorkoutStore)
+
+	store := store.Storage{
+		Workouts: mockWorkoutStore,
+		Users:    new(mocks.MockUserStore),
+	}
+
+	app := newTestApplication(t, store)
+	mux := app.mount()
+
+	tests := []struct {
+		name               string
+		workoutID          int64
+		payload            []byte
+		expectedStatusCode int
+	}{
+		{"should return 400 - invalid difficulty", 1, []byte(`{"difficulty": "expert"}`), http.StatusBadRequest},
+		{"should return 400 - unknown field", 1, []byte(`{"title": "Test Name"}`), http.StatusBadRequest},
+		{"should return 400 - duplicate secondary targets", 1, []byte(`{"secondary_targets": [3, 3]}`), http.StatusBadRequest},
+		{"should return 400 - primary target also secondary", 1, []byte(`{"primary_target": 2}`), http.StatusBadRequest},
+		{"should return 400 - untargeted workout without primary target", mocks.MockUntargetedWorkoutID, []byte(`{"name": "Updated Name"}`), http.StatusBadRequest},
+		{"should return 200 - partial update", 1, []byte(`{"name": "Updated Name"}`), http.StatusOK},
+		{"should return 200 - relink targets", 1, []byte(`{"primary_target": 3, "secondary_targets": []}`), http.StatusOK},
+		{"should return 200 - untargeted workout with primary target", mocks.MockUntargetedWorkoutID, []byte(`{"primary_target": 3}`), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newPatchWorkoutRequest(tt.workoutID, tt.payload)
+
+			res := execRequest(mux, authorize(t, app, req, 1))
+
+			assertStatusCode(t, res.Code, tt.expectedStatusCode)
+
+			assertContentType(t, res.Header().Get("content-type"), contentTypeFor(res.Code))
+		})
+	}
+}
+
+func TestDeleteWorkout(t *testing.T) {
+	mockWorkoutStore := new(mocks.MockWorkoutStore)
+
+	store := store.Storage{
+		Workouts: mockWorkoutStore,
+		Users:    new(mocks.MockUserStore),
+		TOTP:     new(mocks.MockTOTPStore),
+	}
+
+	app := newTestApplication(t, store)
+	mux := app.mount()
+
+	t.Run("should return 204", func(t *testing.T) {
+		req := stepUp(t, newDeleteWorkoutRequest(1))
+
+		res := execRequest(mux, authorize(t, app, req, 1))
+
+		assertStatusCode(t, res.Code, http.StatusNoContent)
+	})
+
+	t.Run("should return 400 - invalid id", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s", WorkoutUrl, "abc"), nil)
+
+		res := execRequest(mux, authorize(t, app, req, 1))
+
+		assertStatusCode(t, res.Code, http.StatusBadRequest)
+	})
+}
+
+func newPatchWorkoutRequest(id int64, payload []byte) *http.Request {
+	req := newJSONRequest(http.MethodPatch, fmt.Sprintf("%s/%d", WorkoutUrl, id), bytes.NewReader(payload))
+	return req
+}
+
+func newDeleteWorkoutRequest(id int64) *http.Request {
+	req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%d", WorkoutUrl, id), nil)
+	return req
+}
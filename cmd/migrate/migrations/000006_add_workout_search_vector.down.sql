@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS target_search_vector_update ON target;
+DROP TRIGGER IF EXISTS equipment_search_vector_update ON equipment;
+DROP TRIGGER IF EXISTS body_part_search_vector_update ON body_part;
+DROP TRIGGER IF EXISTS workout_target_search_vector_update ON workout_target;
+DROP TRIGGER IF EXISTS workout_search_vector_update ON workout;
+DROP FUNCTION IF EXISTS catalog_name_search_vector_trigger();
+DROP FUNCTION IF EXISTS workout_target_search_vector_trigger();
+DROP FUNCTION IF EXISTS workout_search_vector_trigger();
+DROP FUNCTION IF EXISTS workout_search_document(bigint);
+DROP INDEX IF EXISTS workout_search_vector_idx;
+ALTER TABLE workout DROP COLUMN IF EXISTS search_vector;
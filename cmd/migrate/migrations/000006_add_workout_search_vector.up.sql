@@ -0,0 +1,85 @@
+ALTER TABLE workout ADD COLUMN search_vector tsvector;
+
+-- workout_search_document builds the weighted document a workout is searched by:
+-- its name, then the names of its body part, equipment and targets, then its instructions.
+CREATE FUNCTION workout_search_document(wid bigint) RETURNS tsvector
+LANGUAGE sql STABLE AS $$
+    SELECT
+        setweight(to_tsvector('english', w.name), 'A') ||
+        setweight(to_tsvector('english', concat_ws(' ',
+            b.name,
+            e.name,
+            (SELECT string_agg(t.name, ' ')
+             FROM workout_target wt
+             JOIN target t ON t.id = wt.target_id
+             WHERE wt.workout_id = w.id)
+        )), 'B') ||
+        setweight(to_tsvector('english', coalesce(array_to_string(w.instructions, ' '), '')), 'C')
+    FROM workout w
+    JOIN body_part b ON b.id = w.bodypart_id
+    LEFT JOIN equipment e ON e.id = w.equipment_id
+    WHERE w.id = wid
+$$;
+
+CREATE FUNCTION workout_search_vector_trigger() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    UPDATE workout SET search_vector = workout_search_document(NEW.id) WHERE id = NEW.id;
+    RETURN NULL;
+END;
+$$;
+
+CREATE FUNCTION workout_target_search_vector_trigger() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        UPDATE workout SET search_vector = workout_search_document(NEW.workout_id) WHERE id = NEW.workout_id;
+    END IF;
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE workout SET search_vector = workout_search_document(OLD.workout_id) WHERE id = OLD.workout_id;
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+CREATE FUNCTION catalog_name_search_vector_trigger() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    IF TG_TABLE_NAME = 'body_part' THEN
+        UPDATE workout SET search_vector = workout_search_document(id) WHERE bodypart_id = NEW.id;
+    ELSIF TG_TABLE_NAME = 'equipment' THEN
+        UPDATE workout SET search_vector = workout_search_document(id) WHERE equipment_id = NEW.id;
+    ELSE
+        UPDATE workout SET search_vector = workout_search_document(id)
+        WHERE id IN (SELECT workout_id FROM workout_target WHERE target_id = NEW.id);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER workout_search_vector_update
+AFTER INSERT OR UPDATE OF name, instructions, bodypart_id, equipment_id ON workout
+FOR EACH ROW EXECUTE FUNCTION workout_search_vector_trigger();
+
+CREATE TRIGGER workout_target_search_vector_update
+AFTER INSERT OR UPDATE OR DELETE ON workout_target
+FOR EACH ROW EXECUTE FUNCTION workout_target_search_vector_trigger();
+
+CREATE TRIGGER body_part_search_vector_update
+AFTER UPDATE OF name ON body_part
+FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+EXECUTE FUNCTION catalog_name_search_vector_trigger();
+
+CREATE TRIGGER equipment_search_vector_update
+AFTER UPDATE OF name ON equipment
+FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+EXECUTE FUNCTION catalog_name_search_vector_trigger();
+
+CREATE TRIGGER target_search_vector_update
+AFTER UPDATE OF name ON target
+FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+EXECUTE FUNCTION catalog_name_search_vector_trigger();
+
+UPDATE workout SET search_vector = workout_search_document(id);
+
+CREATE INDEX workout_search_vector_idx ON workout USING GIN (search_vector);
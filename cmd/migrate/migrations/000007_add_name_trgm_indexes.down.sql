@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS body_part_name_trgm_idx;
+DROP INDEX IF EXISTS equipment_name_trgm_idx;
+DROP INDEX IF EXISTS target_name_trgm_idx;
+DROP INDEX IF EXISTS workout_name_trgm_idx;
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,6 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX workout_name_trgm_idx ON workout USING GIN (name gin_trgm_ops);
+CREATE INDEX target_name_trgm_idx ON target USING GIN (name gin_trgm_ops);
+CREATE INDEX equipment_name_trgm_idx ON equipment USING GIN (name gin_trgm_ops);
+CREATE INDEX body_part_name_trgm_idx ON body_part USING GIN (name gin_trgm_ops);
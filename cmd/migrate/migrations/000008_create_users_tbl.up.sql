@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS citext;
+CREATE TABLE users (
+    id bigserial PRIMARY KEY,
+    username varchar(40) UNIQUE NOT NULL,
+    email citext UNIQUE NOT NULL,
+    password bytea NOT NULL,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+CREATE TYPE user_role AS ENUM ('member', 'coach', 'admin');
+ALTER TABLE users ADD COLUMN role user_role NOT NULL DEFAULT 'member';
@@ -0,0 +1,12 @@
+CREATE TABLE api_keys (
+    id bigserial PRIMARY KEY,
+    name varchar(100) NOT NULL,
+    prefix varchar(16) UNIQUE NOT NULL,
+    key_hash bytea UNIQUE NOT NULL,
+    scopes text[] NOT NULL DEFAULT '{}',
+    created_by bigint REFERENCES users(id) ON DELETE SET NULL,
+    expires_at timestamp(0) with time zone,
+    revoked_at timestamp(0) with time zone,
+    last_used_at timestamp(0) with time zone,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,13 @@
+-- existing accounts stay usable, new ones start inactive until verified
+ALTER TABLE users ADD COLUMN is_active boolean NOT NULL DEFAULT true;
+ALTER TABLE users ALTER COLUMN is_active SET DEFAULT false;
+
+CREATE TABLE user_tokens (
+    hash bytea PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    scope varchar(20) NOT NULL,
+    expires_at timestamp(0) with time zone NOT NULL,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_tokens_user_id ON user_tokens(user_id);
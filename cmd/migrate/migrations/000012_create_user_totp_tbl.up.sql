@@ -0,0 +1,20 @@
+CREATE TABLE user_totp (
+    user_id bigint PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret varchar(64) NOT NULL,
+    confirmed_at timestamp(0) with time zone,
+    -- last accepted time step, a code is only accepted for a later step
+    last_used_step bigint NOT NULL DEFAULT 0,
+    -- step-up attempts since the last accepted code, counted before the
+    -- code is checked; reaching the limit locks step-up until locked_until
+    failed_attempts int NOT NULL DEFAULT 0,
+    locked_until timestamp(0) with time zone,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE user_recovery_codes (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash bytea NOT NULL,
+    used_at timestamp(0) with time zone,
+    UNIQUE (user_id, code_hash)
+);
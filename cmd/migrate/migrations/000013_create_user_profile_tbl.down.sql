@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_profile;
+DROP TYPE IF EXISTS profile_goal;
+DROP TYPE IF EXISTS profile_sex;
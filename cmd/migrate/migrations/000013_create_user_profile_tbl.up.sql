@@ -0,0 +1,18 @@
+CREATE TYPE profile_sex AS ENUM ('female', 'male', 'other');
+CREATE TYPE profile_goal AS ENUM ('strength', 'hypertrophy', 'endurance', 'fat_loss', 'general_fitness');
+
+-- measurements are stored metric, weight_unit and length_unit only control
+-- how they are presented; three decimals keep imperial input stable when
+-- converted back, e.g. 180 lb stays 180 lb rather than 179.9
+CREATE TABLE user_profile (
+    user_id bigint PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    height_cm numeric(7, 3) CHECK (height_cm > 0),
+    weight_kg numeric(7, 3) CHECK (weight_kg > 0),
+    birth_year smallint,
+    sex profile_sex,
+    experience_level workout_difficulty,
+    goal profile_goal,
+    weight_unit varchar(2) NOT NULL DEFAULT 'kg' CHECK (weight_unit IN ('kg', 'lb')),
+    length_unit varchar(2) NOT NULL DEFAULT 'cm' CHECK (length_unit IN ('cm', 'in')),
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS routine_item;
+DROP TABLE IF EXISTS routine;
@@ -0,0 +1,26 @@
+CREATE TABLE routine (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name varchar(100) NOT NULL,
+    description varchar(500) NOT NULL DEFAULT '',
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_routine_user_id ON routine(user_id);
+
+CREATE TABLE routine_item (
+    id bigserial PRIMARY KEY,
+    routine_id bigint NOT NULL REFERENCES routine(id) ON DELETE CASCADE,
+    workout_id bigint NOT NULL REFERENCES workout(id) ON DELETE CASCADE,
+    position integer NOT NULL,
+    sets smallint NOT NULL CHECK (sets > 0),
+    reps_min smallint CHECK (reps_min > 0),
+    reps_max smallint CHECK (reps_max >= reps_min),
+    target_load_kg numeric(6, 2) CHECK (target_load_kg >= 0),
+    duration_seconds integer CHECK (duration_seconds > 0),
+    rest_seconds integer NOT NULL DEFAULT 60 CHECK (rest_seconds >= 0),
+    UNIQUE (routine_id, position)
+);
+
+CREATE INDEX idx_routine_item_workout_id ON routine_item(workout_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS circuit_member;
+DROP TABLE IF EXISTS circuit;
+DROP TYPE IF EXISTS circuit_kind;
@@ -0,0 +1,27 @@
+CREATE TYPE circuit_kind AS ENUM ('circuit', 'superset');
+
+-- members are performed back-to-back in position order for every round,
+-- rest_seconds separates members and round_rest_seconds separates rounds
+CREATE TABLE circuit (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name varchar(100) NOT NULL,
+    kind circuit_kind NOT NULL DEFAULT 'circuit',
+    rounds smallint NOT NULL CHECK (rounds > 0),
+    work_seconds integer NOT NULL CHECK (work_seconds > 0),
+    rest_seconds integer NOT NULL DEFAULT 0 CHECK (rest_seconds >= 0),
+    round_rest_seconds integer NOT NULL DEFAULT 0 CHECK (round_rest_seconds >= 0),
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE circuit_member (
+    id bigserial PRIMARY KEY,
+    circuit_id bigint NOT NULL REFERENCES circuit(id) ON DELETE CASCADE,
+    workout_id bigint NOT NULL REFERENCES workout(id) ON DELETE CASCADE,
+    position integer NOT NULL,
+    work_seconds integer CHECK (work_seconds > 0),
+    UNIQUE (circuit_id, position)
+);
+
+CREATE INDEX idx_circuit_member_workout_id ON circuit_member(workout_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS session_set;
+DROP TABLE IF EXISTS session;
@@ -0,0 +1,27 @@
+CREATE TABLE session (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    routine_id bigint REFERENCES routine(id) ON DELETE SET NULL,
+    notes varchar(500) NOT NULL DEFAULT '',
+    started_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    finished_at timestamp(0) with time zone CHECK (finished_at >= started_at)
+);
+
+CREATE INDEX idx_session_user_id_started_at ON session(user_id, started_at);
+
+-- a set records at least one of reps, duration or distance
+CREATE TABLE session_set (
+    id bigserial PRIMARY KEY,
+    session_id bigint NOT NULL REFERENCES session(id) ON DELETE CASCADE,
+    workout_id bigint NOT NULL REFERENCES workout(id) ON DELETE CASCADE,
+    set_number integer NOT NULL,
+    reps smallint CHECK (reps >= 0),
+    load_kg numeric(6, 2) CHECK (load_kg >= 0),
+    rpe numeric(3, 1) CHECK (rpe BETWEEN 1 AND 10),
+    duration_seconds integer CHECK (duration_seconds > 0),
+    distance_meters numeric(8, 1) CHECK (distance_meters > 0),
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    CHECK (num_nonnulls(reps, duration_seconds, distance_meters) > 0),
+    -- also serves lookups by session_id
+    CONSTRAINT session_set_number_key UNIQUE (session_id, workout_id, set_number)
+);
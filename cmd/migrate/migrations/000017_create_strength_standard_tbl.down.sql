@@ -0,0 +1 @@
+DROP TABLE IF EXISTS strength_standard;
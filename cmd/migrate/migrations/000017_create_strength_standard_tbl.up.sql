@@ -0,0 +1,12 @@
+-- 1RM to bodyweight ratios a lifter needs to reach each level on a workout
+CREATE TABLE strength_standard (
+    workout_id bigint NOT NULL REFERENCES workout(id) ON DELETE CASCADE,
+    sex profile_sex NOT NULL,
+    beginner numeric(4, 2) NOT NULL CHECK (beginner > 0),
+    novice numeric(4, 2) NOT NULL CHECK (novice > beginner),
+    intermediate numeric(4, 2) NOT NULL CHECK (intermediate > novice),
+    advanced numeric(4, 2) NOT NULL CHECK (advanced > intermediate),
+    elite numeric(4, 2) NOT NULL CHECK (elite > advanced),
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (workout_id, sex)
+);
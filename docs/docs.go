@@ -24,51 +24,49 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/bodyparts": {
+        "/admin/log-level": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Fetch all body parts",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the minimum level the service currently logs",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "body parts"
+                    "admin"
                 ],
-                "summary": "Fetch all body parts",
+                "summary": "Get the log level",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/store.BodyPart"
-                            }
+                            "$ref": "#/definitions/main.LogLevel"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
-                        "schema": {}
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Creates a body part",
+                "description": "Change the minimum level the service logs until it restarts",
                 "consumes": [
                     "application/json"
                 ],
@@ -76,141 +74,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "body parts"
+                    "admin"
                 ],
-                "summary": "Creates a body part",
+                "summary": "Update the log level",
                 "parameters": [
                     {
-                        "description": "BodyPart payload",
+                        "description": "Log level payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/main.CreateBodyPartPayload"
+                            "$ref": "#/definitions/main.UpdateLogLevelPayload"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/store.BodyPart"
+                            "$ref": "#/definitions/main.LogLevel"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "403": {
                         "description": "Forbidden",
-                        "schema": {}
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {}
-                    }
-                }
-            }
-        },
-        "/bodyparts/{bodyPartId}": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Fetches a body part by ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "body parts"
-                ],
-                "summary": "Fetches a body part",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Body Part ID",
-                        "name": "bodyPartId",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/store.BodyPart"
+                            "$ref": "#/definitions/main.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {}
-                    },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/analysis/muscle-load": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Deletes a body part by ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "body parts"
-                ],
-                "summary": "Deletes a body part",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Body Part ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {}
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {}
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {}
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {}
-                    }
-                }
-            },
-            "patch": {
-                "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Update a body part by ID",
+                "description": "Attributes planned sets to the targets and body parts of each workout, with secondary targets weighted at a fraction, and flags imbalances such as push against pull",
                 "consumes": [
                     "application/json"
                 ],
@@ -218,24 +140,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "body parts"
+                    "analysis"
                 ],
-                "summary": "Update a body part",
+                "summary": "Analyzes muscle load",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Body Part ID",
-                        "name": "bodyPartId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Body Part ID",
-                        "name": "bodyPartId",
+                        "description": "Planned workouts",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/main.UpdateBodyPartPayload"
+                            "$ref": "#/definitions/main.MuscleLoadPayload"
                         }
                     }
                 ],
@@ -243,36 +158,32 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/store.BodyPart"
+                            "$ref": "#/definitions/main.MuscleLoadResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
-                        "schema": {}
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {}
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     }
                 }
             }
         },
-        "/equipment": {
+        "/api-keys": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Fetch all equipment",
+                "description": "Fetches all API keys including revoked and expired ones",
                 "consumes": [
                     "application/json"
                 ],
@@ -280,36 +191,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "api keys"
                 ],
-                "summary": "Fetch all equipment",
+                "summary": "Fetches API keys",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/store.Equipment"
+                                "$ref": "#/definitions/store.APIKey"
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     }
                 }
             },
             "post": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Creates a equipment",
+                "description": "Creates a service API key, the key is only returned in this response",
                 "consumes": [
                     "application/json"
                 ],
@@ -317,17 +238,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "api keys"
                 ],
-                "summary": "Creates a equipment",
+                "summary": "Creates an API key",
                 "parameters": [
                     {
-                        "description": "Equipment payload",
+                        "description": "API key payload",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/main.CreateEquipmentPayload"
+                            "$ref": "#/definitions/main.CreateAPIKeyPayload"
                         }
                     }
                 ],
@@ -335,32 +256,44 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/store.Equipment"
+                            "$ref": "#/definitions/main.CreatedAPIKeyResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "403": {
                         "description": "Forbidden",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     }
                 }
             }
         },
-        "/equipment/{equipmentId}": {
+        "/api-keys/{keyId}": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Fetches a equipment by ID",
+                "description": "Fetches an API key by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -368,14 +301,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "api keys"
                 ],
-                "summary": "Fetches a equipment",
+                "summary": "Fetches an API key",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Equipment ID",
-                        "name": "equipmentId",
+                        "description": "API Key ID",
+                        "name": "keyId",
                         "in": "path",
                         "required": true
                     }
@@ -384,26 +317,42 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/store.Equipment"
+                            "$ref": "#/definitions/store.APIKey"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     }
                 }
             },
             "delete": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a equipment by ID",
+                "description": "Revokes an API key by ID, the key stays listed with its revocation time",
                 "consumes": [
                     "application/json"
                 ],
@@ -411,14 +360,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "api keys"
                 ],
-                "summary": "Deletes a equipment",
+                "summary": "Revokes an API key",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Equipment ID",
-                        "name": "id",
+                        "description": "API Key ID",
+                        "name": "keyId",
                         "in": "path",
                         "required": true
                     }
@@ -427,31 +376,39 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {}
-                    },
                     "401": {
                         "description": "Unauthorized",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "404": {
                         "description": "Not Found",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     }
                 }
             },
             "patch": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Update a equipment by ID",
+                "description": "Update when an API key expires",
                 "consumes": [
                     "application/json"
                 ],
@@ -459,24 +416,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "equipment"
+                    "api keys"
                 ],
-                "summary": "Update a equipment",
+                "summary": "Update an API key's expiry",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Equipment ID",
-                        "name": "equipmentId",
+                        "description": "API Key ID",
+                        "name": "keyId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Equipment ID",
-                        "name": "equipmentId",
+                        "description": "Expiry payload",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/main.UpdateEquipmentPayload"
+                            "$ref": "#/definitions/main.UpdateAPIKeyPayload"
                         }
                     }
                 ],
@@ -484,36 +441,51 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/store.Equipment"
+                            "$ref": "#/definitions/store.APIKey"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "404": {
                         "description": "Not Found",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     }
                 }
             }
         },
-        "/targets": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Fetch all target",
+        "/auth/activate": {
+            "post": {
+                "description": "Activates a user with the token from their invitation email",
                 "consumes": [
                     "application/json"
                 ],
@@ -521,36 +493,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "targets"
+                    "authentication"
+                ],
+                "summary": "Activates a user",
+                "parameters": [
+                    {
+                        "description": "Activation token",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ActivateUserPayload"
+                        }
+                    }
                 ],
-                "summary": "Fetch all target",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/store.Target"
-                            }
+                            "$ref": "#/definitions/store.User"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {}
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/auth/login": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Creates a target",
+                "description": "Verifies a user's email and password and issues a bearer access token",
                 "consumes": [
                     "application/json"
                 ],
@@ -558,50 +539,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "targets"
+                    "authentication"
                 ],
-                "summary": "Creates a target",
+                "summary": "Logs a user in",
                 "parameters": [
                     {
-                        "description": "Target payload",
+                        "description": "User credentials",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/main.CreateTargetPayload"
+                            "$ref": "#/definitions/main.LoginUserPayload"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/store.Target"
+                            "$ref": "#/definitions/main.AuthTokenResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "403": {
                         "description": "Forbidden",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     }
                 }
             }
         },
-        "/targets/{targetId}": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Fetches a target by ID",
+        "/auth/password/forgot": {
+            "post": {
+                "description": "Emails a password reset link to an active account. The response is the same whether or not the account exists",
                 "consumes": [
                     "application/json"
                 ],
@@ -609,42 +597,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "targets"
+                    "authentication"
                 ],
-                "summary": "Fetches a target",
+                "summary": "Requests a password reset",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Target ID",
-                        "name": "targetId",
-                        "in": "path",
-                        "required": true
+                        "description": "Account email",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ForgotPasswordPayload"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/store.Target"
+                            "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {}
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Deletes a target by ID",
+            }
+        },
+        "/auth/password/reset": {
+            "post": {
+                "description": "Sets a new password with the token from a password reset email",
                 "consumes": [
                     "application/json"
                 ],
@@ -652,47 +643,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "targets"
+                    "authentication"
                 ],
-                "summary": "Deletes a target",
+                "summary": "Resets a password",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Target ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Reset token and new password",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ResetPasswordPayload"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.User"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
-                        "schema": {}
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {}
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     }
                 }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Update a target by ID",
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Registers an inactive user and emails them an activation link",
                 "consumes": [
                     "application/json"
                 ],
@@ -700,61 +689,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "targets"
+                    "authentication"
                 ],
-                "summary": "Update a target",
+                "summary": "Registers a user",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Target ID",
-                        "name": "targetId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Target ID",
-                        "name": "targetId",
+                        "description": "User credentials",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/main.UpdateTargetPayload"
+                            "$ref": "#/definitions/main.RegisterUserPayload"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/store.Target"
+                            "$ref": "#/definitions/store.User"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
-                        "schema": {}
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {}
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     }
                 }
             }
         },
-        "/workouts": {
-            "get": {
+        "/auth/totp": {
+            "delete": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Fetch all workout",
+                "description": "Removes the current user's TOTP secret and recovery codes",
                 "consumes": [
                     "application/json"
                 ],
@@ -762,36 +746,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "workouts"
+                    "authentication"
+                ],
+                "summary": "Removes TOTP enrollment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current TOTP code or an unused recovery code",
+                        "name": "X-TOTP-Code",
+                        "in": "header",
+                        "required": true
+                    }
                 ],
-                "summary": "Fetch all workout",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/store.PresentableWorkout"
-                            }
+                            "$ref": "#/definitions/main.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/auth/totp/confirm": {
             "post": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Creates a workout",
+                "description": "Confirms a pending enrollment with a current code and returns single-use recovery codes, which are only shown once",
                 "consumes": [
                     "application/json"
                 ],
@@ -799,50 +810,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "workouts"
+                    "authentication"
                 ],
-                "summary": "Creates a workout",
+                "summary": "Confirms TOTP enrollment",
                 "parameters": [
                     {
-                        "description": "Workout payload",
+                        "description": "Current TOTP code",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/main.CreateWorkoutPayload"
+                            "$ref": "#/definitions/main.ConfirmTOTPPayload"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/store.Workout"
+                            "$ref": "#/definitions/main.RecoveryCodesResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "403": {
                         "description": "Forbidden",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
                     }
                 }
             }
         },
-        "/workouts/{workoutId}": {
-            "get": {
+        "/auth/totp/enroll": {
+            "post": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Fetches a workout by ID",
+                "description": "Generates a TOTP secret for the current user. Scan the QR code or URI with an authenticator app, then confirm with a code",
                 "consumes": [
                     "application/json"
                 ],
@@ -850,94 +879,4519 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "workouts"
-                ],
-                "summary": "Fetches a workout",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Workout ID",
-                        "name": "workoutId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "authentication"
                 ],
+                "summary": "Starts TOTP enrollment",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/store.PresentableWorkout"
+                            "$ref": "#/definitions/main.TOTPEnrollmentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/autocomplete": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Typo-tolerant suggestions for workout, target, equipment and body part names",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Suggest catalog names",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Partial name as typed",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "workout,target,equipment,bodypart",
+                        "description": "Comma separated suggestion types",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of suggestions (1-25)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/store.Suggestion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/bodyparts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch a page of body parts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "body parts"
+                ],
+                "summary": "Fetch all body parts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from a previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id",
+                            "name",
+                            "-name"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/store.BodyPart"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a body part",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "body parts"
+                ],
+                "summary": "Creates a body part",
+                "parameters": [
+                    {
+                        "description": "BodyPart payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.CreateBodyPartPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/store.BodyPart"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/bodyparts/{bodyPartId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches a body part by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "body parts"
+                ],
+                "summary": "Fetches a body part",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Body Part ID",
+                        "name": "bodyPartId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.BodyPart"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {}
-                    },
                     "500": {
                         "description": "Internal Server Error",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
+                ],
+                "description": "Deletes a body part by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "body parts"
+                ],
+                "summary": "Deletes a body part",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Body Part ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Current TOTP code or an unused recovery code",
+                        "name": "X-TOTP-Code",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a body part by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "body parts"
+                ],
+                "summary": "Update a body part",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Body Part ID",
+                        "name": "bodyPartId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body Part ID",
+                        "name": "bodyPartId",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.UpdateBodyPartPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.BodyPart"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/circuits": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches all circuit and superset templates with their members expanded",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "circuits"
+                ],
+                "summary": "Fetches circuits",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/store.Circuit"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a circuit or superset template from catalog workouts, members keep the order given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "circuits"
+                ],
+                "summary": "Creates a circuit",
+                "parameters": [
+                    {
+                        "description": "Circuit payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.CreateCircuitPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/store.Circuit"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/circuits/{circuitId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches a circuit by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "circuits"
+                ],
+                "summary": "Fetches a circuit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Circuit ID",
+                        "name": "circuitId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.Circuit"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a circuit owned by the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "circuits"
+                ],
+                "summary": "Deletes a circuit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Circuit ID",
+                        "name": "circuitId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates a circuit owned by the current user, a list of members replaces the existing ones",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "circuits"
+                ],
+                "summary": "Updates a circuit",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Circuit ID",
+                        "name": "circuitId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Circuit payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.UpdateCircuitPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.Circuit"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/circuits/{circuitId}/timeline": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Expands a circuit into its work and rest phases with start offsets in seconds",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "circuits"
+                ],
+                "summary": "Fetches a circuit's timeline",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Circuit ID",
+                        "name": "circuitId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.CircuitTimelineResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch a page of equipment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Fetch all equipment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from a previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id",
+                            "name",
+                            "-name"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/store.Equipment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a equipment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Creates a equipment",
+                "parameters": [
+                    {
+                        "description": "Equipment payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.CreateEquipmentPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/store.Equipment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/equipment/{equipmentId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches a equipment by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Fetches a equipment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Equipment ID",
+                        "name": "equipmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.Equipment"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a equipment by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Deletes a equipment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Equipment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Current TOTP code or an unused recovery code",
+                        "name": "X-TOTP-Code",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a equipment by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Update a equipment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Equipment ID",
+                        "name": "equipmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Equipment ID",
+                        "name": "equipmentId",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.UpdateEquipmentPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.Equipment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/generate/session": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Selects and orders catalog workouts for the given body parts, equipment and difficulty ceiling within a time budget, covering the requested targets first with one workout per primary target. The same payload and seed always give the same session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "generate"
+                ],
+                "summary": "Generates a session",
+                "parameters": [
+                    {
+                        "description": "Session constraints",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.GenerateSessionPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.GeneratedSessionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches the current user's fitness profile, with height and weight in their preferred units",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Fetches the current user's profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.ProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the current user's fitness profile, creating it on first use",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Updates the current user's profile",
+                "parameters": [
+                    {
+                        "description": "Profile payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.UpdateProfilePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.ProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/routines": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches the current user's routines with their items expanded",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routines"
+                ],
+                "summary": "Fetches routines",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/store.Routine"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a routine for the current user from catalog workouts, items keep the order given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routines"
+                ],
+                "summary": "Creates a routine",
+                "parameters": [
+                    {
+                        "description": "Routine payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.CreateRoutinePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/store.Routine"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/routines/{routineId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches one of the current user's routines by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routines"
+                ],
+                "summary": "Fetches a routine",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Routine ID",
+                        "name": "routineId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.Routine"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes one of the current user's routines by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routines"
+                ],
+                "summary": "Deletes a routine",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Routine ID",
+                        "name": "routineId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates one of the current user's routines, a list of items replaces the existing ones",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "routines"
+                ],
+                "summary": "Updates a routine",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Routine ID",
+                        "name": "routineId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Routine payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.UpdateRoutinePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.Routine"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Full-text search over workout names, instructions and their body part, target and equipment names",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search the exercise catalog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search terms, supports quoted phrases, OR and -exclusions",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of results (1-50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/store.WorkoutSearchResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches the current user's sessions with per-session totals, optionally within a date range",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Fetches session history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sessions started at or after, RFC 3339 or YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sessions started before, RFC 3339 or YYYY-MM-DD (inclusive day)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from a previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by id or started_at, prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/store.Session"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Starts a workout session for the current user, optionally following one of their routines",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Starts a session",
+                "parameters": [
+                    {
+                        "description": "Session payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.CreateSessionPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/store.Session"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches one of the current user's sessions with its sets and totals",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Fetches a session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.Session"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionId}/finish": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks one of the current user's sessions as finished, no more sets can be logged afterwards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Finishes a session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.Session"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionId}/sets": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Logs a set against a workout in an unfinished session, sets are numbered per workout",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Logs a set",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Set payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.LogSetPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/store.SessionSet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/targets": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch all target, optionally filtered by body part",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "targets"
+                ],
+                "summary": "Fetch all target",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Body Part ID",
+                        "name": "bodypart_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from a previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id",
+                            "name",
+                            "-name",
+                            "body_part",
+                            "-body_part"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/store.PresentableTarget"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a target",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "targets"
+                ],
+                "summary": "Creates a target",
+                "parameters": [
+                    {
+                        "description": "Target payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.CreateTargetPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/store.Target"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/targets/{targetId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches a target by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "targets"
+                ],
+                "summary": "Fetches a target",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Target ID",
+                        "name": "targetId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.Target"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a target by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "targets"
+                ],
+                "summary": "Deletes a target",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Target ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Current TOTP code or an unused recovery code",
+                        "name": "X-TOTP-Code",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a target by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "targets"
+                ],
+                "summary": "Update a target",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Target ID",
+                        "name": "targetId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target ID",
+                        "name": "targetId",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.UpdateTargetPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.Target"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a user's role by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update a user's role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.UpdateUserRolePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetch all workout, optionally filtered by body part, equipment, difficulty and target",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workouts"
+                ],
+                "summary": "Fetch all workout",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Body Part ID",
+                        "name": "bodypart_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Equipment ID",
+                        "name": "equipment_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "beginner",
+                            "intermediate",
+                            "advanced"
+                        ],
+                        "type": "string",
+                        "description": "Difficulty",
+                        "name": "difficulty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Target ID",
+                        "name": "target_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "primary",
+                            "secondary"
+                        ],
+                        "type": "string",
+                        "description": "Restrict target_id to primary or secondary links",
+                        "name": "target_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from a previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id",
+                            "name",
+                            "-name",
+                            "difficulty",
+                            "-difficulty",
+                            "duration_minutes",
+                            "-duration_minutes",
+                            "calories_burned",
+                            "-calories_burned"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, prefix with - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/store.PresentableWorkout"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a workout",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workouts"
+                ],
+                "summary": "Creates a workout",
+                "parameters": [
+                    {
+                        "description": "Workout payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.CreateWorkoutPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/store.Workout"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/{workoutId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Fetches a workout by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workouts"
+                ],
+                "summary": "Fetches a workout",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "workoutId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.PresentableWorkout"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a workout by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workouts"
+                ],
+                "summary": "Deletes a workout",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Current TOTP code or an unused recovery code",
+                        "name": "X-TOTP-Code",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a workout by ID, replacing its target links when targets are provided",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workouts"
+                ],
+                "summary": "Update a workout",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "workoutId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Workout ID",
+                        "name": "workoutId",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.UpdateWorkoutPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.PresentableWorkout"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/{workoutId}/strength-estimate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Estimates a workout's 1RM from performed sets with the Epley, Brzycki, Lombardi and RPE formulas, along with a 50-100% load table and a strength level when the workout has standards",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workouts"
+                ],
+                "summary": "Estimates a one-rep max",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "workoutId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Performed sets",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.StrengthEstimatePayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.StrengthEstimateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/workouts/{workoutId}/strength-standards": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates or replaces the 1RM to bodyweight ratios for each strength level of a workout and sex",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workouts"
+                ],
+                "summary": "Sets a workout's strength standards",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "workoutId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Strength standard payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.UpdateStrengthStandardPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/store.StrengthStandard"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "analysis.BalanceResult": {
+            "type": "object",
+            "properties": {
+                "denominator_volume": {
+                    "type": "number"
+                },
+                "flagged": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "numerator_volume": {
+                    "type": "number"
+                },
+                "ratio": {
+                    "description": "Ratio is nil when the denominator side has no volume",
+                    "type": "number"
+                }
+            }
+        },
+        "analysis.BodyPartLoad": {
+            "type": "object",
+            "properties": {
+                "body_part": {
+                    "type": "string"
+                },
+                "bodypart_id": {
+                    "type": "integer"
+                },
+                "volume": {
+                    "type": "number"
+                }
+            }
+        },
+        "analysis.TargetLoad": {
+            "type": "object",
+            "properties": {
+                "body_part": {
+                    "type": "string"
+                },
+                "bodypart_id": {
+                    "type": "integer"
+                },
+                "primary_sets": {
+                    "type": "integer"
+                },
+                "secondary_sets": {
+                    "type": "integer"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "integer"
+                },
+                "volume": {
+                    "type": "number"
+                }
+            }
+        },
+        "main.ActivateUserPayload": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "main.AuthTokenResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/store.User"
+                }
+            }
+        },
+        "main.CircuitMemberPayload": {
+            "type": "object",
+            "required": [
+                "workout_id"
+            ],
+            "properties": {
+                "work_seconds": {
+                    "type": "integer",
+                    "maximum": 3600,
+                    "minimum": 1
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "main.CircuitTimelineResponse": {
+            "type": "object",
+            "properties": {
+                "circuit_id": {
+                    "type": "integer"
+                },
+                "phases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/store.CircuitPhase"
+                    }
+                },
+                "total_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "main.ConfirmTOTPPayload": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.CreateAPIKeyPayload": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "main.CreateBodyPartPayload": {
+            "type": "object",
+            "required": [
+                "image_url",
+                "name"
+            ],
+            "properties": {
+                "image_url": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 40
+                }
+            }
+        },
+        "main.CreateCircuitPayload": {
+            "type": "object",
+            "required": [
+                "members",
+                "name",
+                "rounds",
+                "work_seconds"
+            ],
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "circuit",
+                        "superset"
+                    ]
+                },
+                "members": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/main.CircuitMemberPayload"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "rest_seconds": {
+                    "type": "integer",
+                    "maximum": 600,
+                    "minimum": 0
+                },
+                "round_rest_seconds": {
+                    "type": "integer",
+                    "maximum": 1800,
+                    "minimum": 0
+                },
+                "rounds": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1
+                },
+                "work_seconds": {
+                    "type": "integer",
+                    "maximum": 3600,
+                    "minimum": 1
+                }
+            }
+        },
+        "main.CreateEquipmentPayload": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 40
+                }
+            }
+        },
+        "main.CreateRoutinePayload": {
+            "type": "object",
+            "required": [
+                "items",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "items": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/main.RoutineItemPayload"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "main.CreateSessionPayload": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "routine_id": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "description": "StartedAt backdates a session logged after the fact, it defaults to now",
+                    "type": "string"
+                }
+            }
+        },
+        "main.CreateTargetPayload": {
+            "type": "object",
+            "required": [
+                "bodypart_id",
+                "name"
+            ],
+            "properties": {
+                "bodypart_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 40
+                }
+            }
+        },
+        "main.CreateWorkoutPayload": {
+            "type": "object",
+            "required": [
+                "bodypart_id",
+                "difficulty",
+                "equipment_id",
+                "name",
+                "primary_target",
+                "secondary_targets"
+            ],
+            "properties": {
+                "bodypart_id": {
+                    "type": "integer"
+                },
+                "calories_burned": {
+                    "type": "integer"
+                },
+                "difficulty": {
+                    "type": "string",
+                    "enum": [
+                        "beginner",
+                        "intermediate",
+                        "advanced"
+                    ]
+                },
+                "duration_minutes": {
+                    "type": "integer"
+                },
+                "equipment_id": {
+                    "type": "integer"
+                },
+                "gif_url": {
+                    "type": "string"
+                },
+                "instructions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 40
+                },
+                "primary_target": {
+                    "type": "integer"
+                },
+                "secondary_targets": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "main.CreatedAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "main.EstimateSetPayload": {
+            "type": "object",
+            "properties": {
+                "load": {
+                    "type": "number",
+                    "maximum": 2000
+                },
+                "reps": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1
+                },
+                "rpe": {
+                    "type": "number",
+                    "maximum": 10,
+                    "minimum": 1
+                }
+            }
+        },
+        "main.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "tag": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.ForgotPasswordPayload": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "main.GenerateSessionPayload": {
+            "type": "object",
+            "required": [
+                "bodypart_ids",
+                "time_budget_minutes"
+            ],
+            "properties": {
+                "bodypart_ids": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "equipment_ids": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "max_difficulty": {
+                    "type": "string",
+                    "enum": [
+                        "beginner",
+                        "intermediate",
+                        "advanced"
+                    ]
+                },
+                "seed": {
+                    "type": "integer",
+                    "maximum": 9007199254740991,
+                    "minimum": 0
+                },
+                "target_ids": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "time_budget_minutes": {
+                    "type": "integer",
+                    "maximum": 240,
+                    "minimum": 5
+                }
+            }
+        },
+        "main.GeneratedSessionResponse": {
+            "type": "object",
+            "properties": {
+                "covered_target_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "missing_target_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "time_budget_minutes": {
+                    "type": "integer"
+                },
+                "total_minutes": {
+                    "type": "integer"
+                },
+                "workouts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.GeneratedWorkout"
+                    }
+                }
+            }
+        },
+        "main.GeneratedWorkout": {
+            "type": "object",
+            "properties": {
+                "body_part": {
+                    "type": "string"
+                },
+                "bodypart_id": {
+                    "type": "integer"
+                },
+                "calories_burned": {
+                    "type": "integer"
+                },
+                "difficulty": {
+                    "type": "string"
+                },
+                "duration_minutes": {
+                    "type": "integer"
+                },
+                "equipment": {
+                    "type": "string"
+                },
+                "equipment_id": {
+                    "type": "integer"
+                },
+                "gif_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "instructions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "primary_target": {
+                    "type": "string"
+                },
+                "primary_target_id": {
+                    "type": "integer"
+                },
+                "secondary_target_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "secondary_targets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "main.LogLevel": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.LogSetPayload": {
+            "type": "object",
+            "required": [
+                "workout_id"
+            ],
+            "properties": {
+                "distance_meters": {
+                    "type": "number",
+                    "maximum": 1000000
+                },
+                "duration_seconds": {
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 1
+                },
+                "load_kg": {
+                    "type": "number",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "reps": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "rpe": {
+                    "type": "number",
+                    "maximum": 10,
+                    "minimum": 1
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "main.LoginUserPayload": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72
+                }
+            }
+        },
+        "main.MuscleLoadItemPayload": {
+            "type": "object",
+            "required": [
+                "sets",
+                "workout_id"
+            ],
+            "properties": {
+                "sets": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "main.MuscleLoadPayload": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/main.MuscleLoadItemPayload"
+                    }
+                },
+                "secondary_weight": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                }
+            }
+        },
+        "main.MuscleLoadResponse": {
+            "type": "object",
+            "properties": {
+                "balances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analysis.BalanceResult"
+                    }
+                },
+                "body_parts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analysis.BodyPartLoad"
+                    }
+                },
+                "secondary_weight": {
+                    "type": "number"
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analysis.TargetLoad"
+                    }
+                },
+                "total_sets": {
+                    "type": "integer"
+                }
+            }
+        },
+        "main.Problem": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.FieldError"
+                    }
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.ProfileResponse": {
+            "type": "object",
+            "properties": {
+                "birth_year": {
+                    "type": "integer"
+                },
+                "experience_level": {
+                    "type": "string"
+                },
+                "goal": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "number"
+                },
+                "length_unit": {
+                    "type": "string"
+                },
+                "sex": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "main.RegisterUserPayload": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 40
+                }
+            }
+        },
+        "main.ResetPasswordPayload": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                },
+                "token": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "main.RoutineItemPayload": {
+            "type": "object",
+            "required": [
+                "sets",
+                "workout_id"
+            ],
+            "properties": {
+                "duration_seconds": {
+                    "type": "integer",
+                    "maximum": 3600,
+                    "minimum": 1
+                },
+                "reps_max": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "reps_min": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "rest_seconds": {
+                    "type": "integer",
+                    "maximum": 600,
+                    "minimum": 0
+                },
+                "sets": {
+                    "type": "integer",
+                    "maximum": 20,
+                    "minimum": 1
+                },
+                "target_load_kg": {
+                    "type": "number",
+                    "maximum": 1000,
+                    "minimum": 0
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "main.SetEstimate": {
+            "type": "object",
+            "properties": {
+                "formulas": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "load": {
+                    "type": "number"
+                },
+                "one_rep_max": {
+                    "type": "number"
+                },
+                "reps": {
+                    "type": "integer"
+                },
+                "rpe": {
+                    "type": "number"
+                }
+            }
+        },
+        "main.StrengthEstimatePayload": {
+            "type": "object",
+            "required": [
+                "sets"
+            ],
+            "properties": {
+                "bodyweight": {
+                    "type": "number",
+                    "maximum": 1000
+                },
+                "increment": {
+                    "type": "number",
+                    "maximum": 50
+                },
+                "sets": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/main.EstimateSetPayload"
+                    }
+                },
+                "sex": {
+                    "type": "string",
+                    "enum": [
+                        "female",
+                        "male"
+                    ]
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "kg",
+                        "lb"
+                    ]
+                }
+            }
+        },
+        "main.StrengthEstimateResponse": {
+            "type": "object",
+            "properties": {
+                "classification": {
+                    "$ref": "#/definitions/strength.Classification"
+                },
+                "formulas": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "increment": {
+                    "type": "number"
+                },
+                "load_table": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/strength.Load"
+                    }
+                },
+                "one_rep_max": {
+                    "type": "number"
+                },
+                "sets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.SetEstimate"
+                    }
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "main.TOTPEnrollmentResponse": {
+            "type": "object",
+            "properties": {
+                "otpauth_uri": {
+                    "type": "string"
+                },
+                "qr_png": {
+                    "description": "QRCode is a base64 encoded PNG of URI",
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.UpdateAPIKeyPayload": {
+            "type": "object",
+            "required": [
+                "expires_at"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.UpdateBodyPartPayload": {
+            "type": "object",
+            "properties": {
+                "image_url": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 40
+                }
+            }
+        },
+        "main.UpdateCircuitPayload": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "circuit",
+                        "superset"
+                    ]
+                },
+                "members": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/main.CircuitMemberPayload"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "rest_seconds": {
+                    "type": "integer",
+                    "maximum": 600,
+                    "minimum": 0
+                },
+                "round_rest_seconds": {
+                    "type": "integer",
+                    "maximum": 1800,
+                    "minimum": 0
+                },
+                "rounds": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1
+                },
+                "work_seconds": {
+                    "type": "integer",
+                    "maximum": 3600,
+                    "minimum": 1
+                }
+            }
+        },
+        "main.UpdateEquipmentPayload": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 40
                 }
             }
-        }
-    },
-    "definitions": {
-        "main.CreateBodyPartPayload": {
+        },
+        "main.UpdateLogLevelPayload": {
             "type": "object",
             "required": [
-                "image_url",
-                "name"
+                "level"
             ],
             "properties": {
-                "image_url": {
+                "level": {
                     "type": "string",
-                    "maxLength": 255
+                    "enum": [
+                        "debug",
+                        "info",
+                        "warn",
+                        "error"
+                    ]
+                }
+            }
+        },
+        "main.UpdateProfilePayload": {
+            "type": "object",
+            "properties": {
+                "birth_year": {
+                    "type": "integer",
+                    "minimum": 1900
+                },
+                "experience_level": {
+                    "type": "string",
+                    "enum": [
+                        "beginner",
+                        "intermediate",
+                        "advanced"
+                    ]
+                },
+                "goal": {
+                    "type": "string",
+                    "enum": [
+                        "strength",
+                        "hypertrophy",
+                        "endurance",
+                        "fat_loss",
+                        "general_fitness"
+                    ]
+                },
+                "height": {
+                    "type": "number"
+                },
+                "length_unit": {
+                    "type": "string",
+                    "enum": [
+                        "cm",
+                        "in"
+                    ]
+                },
+                "sex": {
+                    "type": "string",
+                    "enum": [
+                        "female",
+                        "male",
+                        "other"
+                    ]
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "type": "string",
+                    "enum": [
+                        "kg",
+                        "lb"
+                    ]
+                }
+            }
+        },
+        "main.UpdateRoutinePayload": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "items": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/main.RoutineItemPayload"
+                    }
                 },
                 "name": {
                     "type": "string",
-                    "maxLength": 40
+                    "maxLength": 100,
+                    "minLength": 1
                 }
             }
         },
-        "main.CreateEquipmentPayload": {
+        "main.UpdateStrengthStandardPayload": {
             "type": "object",
             "required": [
-                "name"
+                "sex"
             ],
             "properties": {
+                "advanced": {
+                    "type": "number",
+                    "maximum": 10
+                },
+                "beginner": {
+                    "type": "number",
+                    "maximum": 10
+                },
+                "elite": {
+                    "type": "number",
+                    "maximum": 10
+                },
+                "intermediate": {
+                    "type": "number",
+                    "maximum": 10
+                },
+                "novice": {
+                    "type": "number",
+                    "maximum": 10
+                },
+                "sex": {
+                    "type": "string",
+                    "enum": [
+                        "female",
+                        "male"
+                    ]
+                }
+            }
+        },
+        "main.UpdateTargetPayload": {
+            "type": "object",
+            "properties": {
+                "bodypart_id": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string",
                     "maxLength": 40
                 }
             }
         },
-        "main.CreateTargetPayload": {
+        "main.UpdateUserRolePayload": {
             "type": "object",
             "required": [
-                "bodypart_id",
-                "name"
+                "role"
             ],
             "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "member",
+                        "coach",
+                        "admin"
+                    ]
+                }
+            }
+        },
+        "main.UpdateWorkoutPayload": {
+            "type": "object",
+            "properties": {
+                "bodypart_id": {
+                    "type": "integer"
+                },
+                "calories_burned": {
+                    "type": "integer"
+                },
+                "difficulty": {
+                    "type": "string",
+                    "enum": [
+                        "beginner",
+                        "intermediate",
+                        "advanced"
+                    ]
+                },
+                "duration_minutes": {
+                    "type": "integer"
+                },
+                "equipment_id": {
+                    "type": "integer"
+                },
+                "gif_url": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "instructions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 40
+                },
+                "primary_target": {
+                    "type": "integer"
+                },
+                "secondary_targets": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "store.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "store.BodyPart": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "store.Circuit": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/store.CircuitMember"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rest_seconds": {
+                    "type": "integer"
+                },
+                "round_rest_seconds": {
+                    "type": "integer"
+                },
+                "rounds": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "work_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "store.CircuitMember": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "work_seconds": {
+                    "description": "WorkSeconds overrides the circuit's work interval for this member",
+                    "type": "integer"
+                },
+                "workout": {
+                    "$ref": "#/definitions/store.PresentableWorkout"
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "store.CircuitPhase": {
+            "type": "object",
+            "properties": {
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "phase": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "start_second": {
+                    "type": "integer"
+                },
+                "workout_id": {
+                    "type": "integer"
+                },
+                "workout_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "store.Equipment": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "store.PresentableTarget": {
+            "type": "object",
+            "properties": {
+                "body_part": {
+                    "type": "string"
+                },
                 "bodypart_id": {
                     "type": "integer"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "name": {
-                    "type": "string",
-                    "maxLength": 40
+                    "type": "string"
                 }
             }
         },
-        "main.CreateWorkoutPayload": {
+        "store.PresentableWorkout": {
             "type": "object",
-            "required": [
-                "bodypart_id",
-                "difficulty",
-                "equipment_id",
-                "name",
-                "primary_target",
-                "secondary_targets"
-            ],
             "properties": {
+                "body_part": {
+                    "type": "string"
+                },
                 "bodypart_id": {
                     "type": "integer"
                 },
@@ -945,22 +5399,23 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "difficulty": {
-                    "type": "string",
-                    "enum": [
-                        "beginner",
-                        "intermediate",
-                        "advanced"
-                    ]
+                    "type": "string"
                 },
                 "duration_minutes": {
                     "type": "integer"
                 },
+                "equipment": {
+                    "type": "string"
+                },
                 "equipment_id": {
                     "type": "integer"
                 },
                 "gif_url": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "instructions": {
                     "type": "array",
                     "items": {
@@ -968,69 +5423,215 @@ const docTemplate = `{
                     }
                 },
                 "name": {
-                    "type": "string",
-                    "maxLength": 40
+                    "type": "string"
                 },
                 "primary_target": {
+                    "type": "string"
+                },
+                "primary_target_id": {
                     "type": "integer"
                 },
-                "secondary_targets": {
+                "secondary_target_ids": {
                     "type": "array",
                     "items": {
                         "type": "integer"
                     }
+                },
+                "secondary_targets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "main.UpdateBodyPartPayload": {
+        "store.Routine": {
             "type": "object",
             "properties": {
-                "image_url": {
-                    "type": "string",
-                    "maxLength": 255
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "estimated_duration_seconds": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/store.RoutineItem"
+                    }
                 },
                 "name": {
-                    "type": "string",
-                    "maxLength": 40
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "main.UpdateEquipmentPayload": {
+        "store.RoutineItem": {
             "type": "object",
             "properties": {
-                "name": {
-                    "type": "string",
-                    "maxLength": 40
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "reps_max": {
+                    "type": "integer"
+                },
+                "reps_min": {
+                    "type": "integer"
+                },
+                "rest_seconds": {
+                    "type": "integer"
+                },
+                "sets": {
+                    "type": "integer"
+                },
+                "target_load_kg": {
+                    "type": "number"
+                },
+                "workout": {
+                    "$ref": "#/definitions/store.PresentableWorkout"
+                },
+                "workout_id": {
+                    "type": "integer"
                 }
             }
         },
-        "main.UpdateTargetPayload": {
+        "store.Session": {
             "type": "object",
             "properties": {
-                "bodypart_id": {
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 40
+                "notes": {
+                    "type": "string"
+                },
+                "routine_id": {
+                    "type": "integer"
+                },
+                "sets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/store.SessionSet"
+                    }
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "totals": {
+                    "$ref": "#/definitions/store.SessionTotals"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "store.BodyPart": {
+        "store.SessionSet": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "distance_meters": {
+                    "type": "number"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "image_url": {
+                "load_kg": {
+                    "type": "number"
+                },
+                "reps": {
+                    "type": "integer"
+                },
+                "rpe": {
+                    "type": "number"
+                },
+                "session_id": {
+                    "type": "integer"
+                },
+                "set_number": {
+                    "type": "integer"
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "store.SessionTotals": {
+            "type": "object",
+            "properties": {
+                "distance_meters": {
+                    "type": "number"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "reps": {
+                    "type": "integer"
+                },
+                "sets": {
+                    "type": "integer"
+                },
+                "volume_kg": {
+                    "type": "number"
+                },
+                "workouts": {
+                    "type": "integer"
+                }
+            }
+        },
+        "store.StrengthStandard": {
+            "type": "object",
+            "properties": {
+                "advanced": {
+                    "type": "number"
+                },
+                "beginner": {
+                    "type": "number"
+                },
+                "elite": {
+                    "type": "number"
+                },
+                "intermediate": {
+                    "type": "number"
+                },
+                "novice": {
+                    "type": "number"
+                },
+                "sex": {
                     "type": "string"
                 },
-                "name": {
+                "updated_at": {
                     "type": "string"
+                },
+                "workout_id": {
+                    "type": "integer"
                 }
             }
         },
-        "store.Equipment": {
+        "store.Suggestion": {
             "type": "object",
             "properties": {
                 "id": {
@@ -1038,70 +5639,93 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "store.PresentableWorkout": {
+        "store.Target": {
             "type": "object",
             "properties": {
-                "body_part": {
-                    "type": "string"
-                },
-                "calories_burned": {
+                "bodypart_id": {
                     "type": "integer"
                 },
-                "difficulty": {
-                    "type": "string"
-                },
-                "duration_minutes": {
+                "id": {
                     "type": "integer"
                 },
-                "equipment": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "store.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "gif_url": {
+                "email": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "instructions": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "is_active": {
+                    "type": "boolean"
                 },
-                "name": {
+                "role": {
                     "type": "string"
                 },
-                "primary_target": {
+                "username": {
                     "type": "string"
-                },
-                "secondary_targets": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
                 }
             }
         },
-        "store.Target": {
+        "store.Workout": {
             "type": "object",
             "properties": {
                 "bodypart_id": {
                     "type": "integer"
                 },
+                "calories_burned": {
+                    "type": "integer"
+                },
+                "difficulty": {
+                    "type": "string"
+                },
+                "duration_minutes": {
+                    "type": "integer"
+                },
+                "equipment_id": {
+                    "type": "integer"
+                },
+                "gif_url": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "instructions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "name": {
                     "type": "string"
                 }
             }
         },
-        "store.Workout": {
+        "store.WorkoutSearchResult": {
             "type": "object",
             "properties": {
+                "body_part": {
+                    "type": "string"
+                },
                 "bodypart_id": {
                     "type": "integer"
                 },
@@ -1114,6 +5738,9 @@ const docTemplate = `{
                 "duration_minutes": {
                     "type": "integer"
                 },
+                "equipment": {
+                    "type": "string"
+                },
                 "equipment_id": {
                     "type": "integer"
                 },
@@ -1131,9 +5758,76 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
+                },
+                "primary_target": {
+                    "type": "string"
+                },
+                "primary_target_id": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "secondary_target_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "secondary_targets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "snippet": {
+                    "type": "string"
+                }
+            }
+        },
+        "strength.Classification": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string"
+                },
+                "next_level": {
+                    "description": "NextLevel and ToNextLevel are empty at elite, ToNextLevel is in the\nsame unit as the 1RM and bodyweight passed in",
+                    "type": "string"
+                },
+                "ratio": {
+                    "type": "number"
+                },
+                "to_next_level": {
+                    "type": "number"
+                }
+            }
+        },
+        "strength.Load": {
+            "type": "object",
+            "properties": {
+                "load": {
+                    "type": "number"
+                },
+                "percent": {
+                    "type": "integer"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "Service API key issued through /api-keys",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "Access token from /auth/login, sent as \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
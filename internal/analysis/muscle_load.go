@@ -0,0 +1,181 @@
+// Package analysis attributes planned training volume to the muscles the
+// catalog links each workout to.
+package analysis
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+const (
+	Primary   = "primary"
+	Secondary = "secondary"
+)
+
+type Target struct {
+	ID         int64
+	Name       string
+	Type       string
+	BodyPartID int64
+	BodyPart   string
+}
+
+// Item is a workout planned for a number of sets along with its targets.
+type Item struct {
+	WorkoutID int64
+	Sets      int
+	Targets   []Target
+}
+
+type TargetLoad struct {
+	TargetID      int64   `json:"target_id"`
+	Target        string  `json:"target"`
+	BodyPartID    int64   `json:"bodypart_id"`
+	BodyPart      string  `json:"body_part"`
+	PrimarySets   int     `json:"primary_sets"`
+	SecondarySets int     `json:"secondary_sets"`
+	Volume        float64 `json:"volume"`
+}
+
+type BodyPartLoad struct {
+	BodyPartID int64   `json:"bodypart_id"`
+	BodyPart   string  `json:"body_part"`
+	Volume     float64 `json:"volume"`
+}
+
+// Balance compares the volume of two opposing muscle groups, given by target
+// name. Ratios outside [Min, Max] are flagged.
+type Balance struct {
+	Name        string
+	Numerator   []string
+	Denominator []string
+	Min         float64
+	Max         float64
+}
+
+// DefaultBalances flags the common imbalances of push against pull and of
+// the front of the thigh against the back.
+var DefaultBalances = []Balance{
+	{
+		Name:        "push_pull",
+		Numerator:   []string{"pectorals", "delts", "triceps", "serratus anterior"},
+		Denominator: []string{"lats", "upper back", "biceps", "traps", "levator scapulae"},
+		Min:         0.67,
+		Max:         1.5,
+	},
+	{
+		Name:        "quads_hamstrings",
+		Numerator:   []string{"quads"},
+		Denominator: []string{"hamstrings"},
+		Min:         0.67,
+		Max:         1.5,
+	},
+}
+
+type BalanceResult struct {
+	Name              string  `json:"name"`
+	NumeratorVolume   float64 `json:"numerator_volume"`
+	DenominatorVolume float64 `json:"denominator_volume"`
+	// Ratio is nil when the denominator side has no volume
+	Ratio   *float64 `json:"ratio"`
+	Flagged bool     `json:"flagged"`
+}
+
+type MuscleLoad struct {
+	TotalSets int             `json:"total_sets"`
+	Targets   []TargetLoad    `json:"targets"`
+	BodyParts []BodyPartLoad  `json:"body_parts"`
+	Balances  []BalanceResult `json:"balances"`
+}
+
+// Analyze attributes each item's sets to its targets, counting a set fully
+// for primary targets and at secondaryWeight for secondary ones. Body part
+// volume follows the body part of each target rather than the workout.
+func Analyze(items []Item, secondaryWeight float64, balances []Balance) MuscleLoad {
+	load := MuscleLoad{
+		Targets:   []TargetLoad{},
+		BodyParts: []BodyPartLoad{},
+		Balances:  []BalanceResult{},
+	}
+
+	targets := make(map[int64]*TargetLoad)
+	bodyParts := make(map[int64]*BodyPartLoad)
+
+	for _, item := range items {
+		load.TotalSets += item.Sets
+
+		for _, t := range item.Targets {
+			tl, ok := targets[t.ID]
+			if !ok {
+				tl = &TargetLoad{TargetID: t.ID, Target: t.Name, BodyPartID: t.BodyPartID, BodyPart: t.BodyPart}
+				targets[t.ID] = tl
+			}
+
+			bl, ok := bodyParts[t.BodyPartID]
+			if !ok {
+				bl = &BodyPartLoad{BodyPartID: t.BodyPartID, BodyPart: t.BodyPart}
+				bodyParts[t.BodyPartID] = bl
+			}
+
+			volume := float64(item.Sets)
+			if t.Type == Secondary {
+				tl.SecondarySets += item.Sets
+				volume *= secondaryWeight
+			} else {
+				tl.PrimarySets += item.Sets
+			}
+
+			tl.Volume += volume
+			bl.Volume += volume
+		}
+	}
+
+	for _, tl := range targets {
+		load.Targets = append(load.Targets, *tl)
+	}
+	slices.SortFunc(load.Targets, func(a, b TargetLoad) int {
+		return cmp.Or(cmp.Compare(b.Volume, a.Volume), cmp.Compare(a.TargetID, b.TargetID))
+	})
+
+	for _, bl := range bodyParts {
+		load.BodyParts = append(load.BodyParts, *bl)
+	}
+	slices.SortFunc(load.BodyParts, func(a, b BodyPartLoad) int {
+		return cmp.Or(cmp.Compare(b.Volume, a.Volume), cmp.Compare(a.BodyPartID, b.BodyPartID))
+	})
+
+	for _, b := range balances {
+		load.Balances = append(load.Balances, b.check(load.Targets))
+	}
+
+	return load
+}
+
+func (b Balance) check(targets []TargetLoad) BalanceResult {
+	res := BalanceResult{Name: b.Name}
+
+	for _, t := range targets {
+		name := strings.ToLower(t.Target)
+		if slices.Contains(b.Numerator, name) {
+			res.NumeratorVolume += t.Volume
+		}
+		if slices.Contains(b.Denominator, name) {
+			res.DenominatorVolume += t.Volume
+		}
+	}
+
+	switch {
+	case res.NumeratorVolume == 0 && res.DenominatorVolume == 0:
+		// nothing to compare
+	case res.DenominatorVolume == 0:
+		// all of the volume is on one side
+		res.Flagged = true
+	default:
+		ratio := res.NumeratorVolume / res.DenominatorVolume
+		res.Ratio = &ratio
+		res.Flagged = ratio < b.Min || ratio > b.Max
+	}
+
+	return res
+}
@@ -0,0 +1,100 @@
+package analysis
+
+import (
+	"testing"
+)
+
+var (
+	pectorals = Target{ID: 1, Name: "pectorals", Type: Primary, BodyPartID: 1, BodyPart: "chest"}
+	triceps   = Target{ID: 2, Name: "triceps", Type: Secondary, BodyPartID: 2, BodyPart: "upper arms"}
+	lats      = Target{ID: 3, Name: "lats", Type: Primary, BodyPartID: 3, BodyPart: "back"}
+	biceps    = Target{ID: 4, Name: "biceps", Type: Secondary, BodyPartID: 2, BodyPart: "upper arms"}
+)
+
+func TestAnalyze(t *testing.T) {
+	items := []Item{
+		{WorkoutID: 1, Sets: 4, Targets: []Target{pectorals, triceps}},
+		{WorkoutID: 2, Sets: 3, Targets: []Target{lats, biceps}},
+	}
+
+	load := Analyze(items, 0.5, DefaultBalances)
+
+	if load.TotalSets != 7 {
+		t.Errorf("got %d total sets want 7", load.TotalSets)
+	}
+
+	wantTargets := []TargetLoad{
+		{TargetID: 1, Target: "pectorals", BodyPartID: 1, BodyPart: "chest", PrimarySets: 4, Volume: 4},
+		{TargetID: 3, Target: "lats", BodyPartID: 3, BodyPart: "back", PrimarySets: 3, Volume: 3},
+		{TargetID: 2, Target: "triceps", BodyPartID: 2, BodyPart: "upper arms", SecondarySets: 4, Volume: 2},
+		{TargetID: 4, Target: "biceps", BodyPartID: 2, BodyPart: "upper arms", SecondarySets: 3, Volume: 1.5},
+	}
+	if len(load.Targets) != len(wantTargets) {
+		t.Fatalf("got %+v want %+v", load.Targets, wantTargets)
+	}
+	for i, want := range wantTargets {
+		if load.Targets[i] != want {
+			t.Errorf("target %d: got %+v want %+v", i, load.Targets[i], want)
+		}
+	}
+
+	wantBodyParts := []BodyPartLoad{
+		{BodyPartID: 1, BodyPart: "chest", Volume: 4},
+		{BodyPartID: 2, BodyPart: "upper arms", Volume: 3.5},
+		{BodyPartID: 3, BodyPart: "back", Volume: 3},
+	}
+	if len(load.BodyParts) != len(wantBodyParts) {
+		t.Fatalf("got %+v want %+v", load.BodyParts, wantBodyParts)
+	}
+	for i, want := range wantBodyParts {
+		if load.BodyParts[i] != want {
+			t.Errorf("body part %d: got %+v want %+v", i, load.BodyParts[i], want)
+		}
+	}
+}
+
+func TestBalances(t *testing.T) {
+	tests := []struct {
+		name        string
+		items       []Item
+		wantRatio   *float64
+		wantFlagged bool
+	}{
+		{"nothing planned", nil, nil, false},
+		{
+			// push 4 + 2 against pull 3 + 1.5
+			"within range",
+			[]Item{{Sets: 4, Targets: []Target{pectorals, triceps}}, {Sets: 3, Targets: []Target{lats, biceps}}},
+			ptr(6 / 4.5),
+			false,
+		},
+		{
+			"push heavy",
+			[]Item{{Sets: 8, Targets: []Target{pectorals}}, {Sets: 3, Targets: []Target{lats}}},
+			ptr(8 / 3.0),
+			true,
+		},
+		{"push only", []Item{{Sets: 3, Targets: []Target{pectorals}}}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Analyze(tt.items, 0.5, DefaultBalances).Balances[0]
+
+			if got.Name != "push_pull" {
+				t.Fatalf("got balance %q", got.Name)
+			}
+
+			if (got.Ratio == nil) != (tt.wantRatio == nil) || (got.Ratio != nil && *got.Ratio != *tt.wantRatio) {
+				t.Errorf("got ratio %v want %v", got.Ratio, tt.wantRatio)
+			}
+
+			if got.Flagged != tt.wantFlagged {
+				t.Errorf("got flagged %v want %v", got.Flagged, tt.wantFlagged)
+			}
+		})
+	}
+}
+
+func ptr(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// Authenticator issues and verifies the access tokens presented as bearer
+// credentials. The subject identifies the authenticated user.
+type Authenticator interface {
+	GenerateToken(subject string) (token string, expiresAt time.Time, err error)
+	ValidateToken(token string) (subject string, err error)
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type JWTAuthenticator struct {
+	secret []byte
+	iss    string
+	exp    time.Duration
+}
+
+func NewJWTAuthenticator(secret, iss string, exp time.Duration) *JWTAuthenticator {
+	return &JWTAuthenticator{
+		secret: []byte(secret),
+		iss:    iss,
+		exp:    exp,
+	}
+}
+
+func (a *JWTAuthenticator) GenerateToken(subject string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(a.exp)
+
+	claims := jwt.RegisteredClaims{
+		Subject:   subject,
+		Issuer:    a.iss,
+		Audience:  jwt.ClaimStrings{a.iss},
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(a.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+func (a *JWTAuthenticator) ValidateToken(token string) (string, error) {
+	var claims jwt.RegisteredClaims
+
+	_, err := jwt.ParseWithClaims(
+		token,
+		&claims,
+		func(t *jwt.Token) (any, error) {
+			return a.secret, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}),
+		jwt.WithIssuer(a.iss),
+		jwt.WithAudience(a.iss),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+
+	return claims.Subject, nil
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJWTAuthenticator(t *testing.T) {
+	a := NewJWTAuthenticator("secret", "mfit", time.Hour)
+
+	token, expiresAt, err := a.GenerateToken("42")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if time.Until(expiresAt) <= 0 {
+		t.Errorf("got expiry %v in the past", expiresAt)
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		subject, err := a.ValidateToken(token)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if subject != "42" {
+			t.Errorf("got subject %q want %q", subject, "42")
+		}
+	})
+
+	tests := []struct {
+		name  string
+		token func() string
+	}{
+		{"malformed token", func() string { return "not-a-token" }},
+		{"tampered token", func() string { return token + "x" }},
+		{"other secret", func() string {
+			token, _, _ := NewJWTAuthenticator("other", "mfit", time.Hour).GenerateToken("42")
+			return token
+		}},
+		{"other issuer", func() string {
+			token, _, _ := NewJWTAuthenticator("secret", "other", time.Hour).GenerateToken("42")
+			return token
+		}},
+		{"expired token", func() string {
+			token, _, _ := NewJWTAuthenticator("secret", "mfit", -time.Minute).GenerateToken("42")
+			return token
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := a.ValidateToken(tt.token()); err != ErrInvalidToken {
+				t.Errorf("got error %v want %v", err, ErrInvalidToken)
+			}
+		})
+	}
+}
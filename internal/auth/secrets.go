@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const apiKeyPrefix = "mfit"
+
+// GenerateAPIKey returns a new API key, the short prefix used to identify it
+// in listings and the hash to persist. The key itself is never stored.
+func GenerateAPIKey() (key, prefix string, hash []byte, err error) {
+	id := make([]byte, 4)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", nil, err
+	}
+
+	secret, err := randomString(32)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	prefix = apiKeyPrefix + "_" + hex.EncodeToString(id)
+	key = prefix + "_" + secret
+
+	return key, prefix, HashAPIKey(key), nil
+}
+
+// HashAPIKey hashes a presented API key for lookup. Keys carry 256 bits of
+// randomness so a fast hash is sufficient.
+func HashAPIKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
+
+// GenerateOneTimeToken returns a random URL-safe token for emailed links
+// along with the hash to persist.
+func GenerateOneTimeToken() (token string, hash []byte, err error) {
+	token, err = randomString(32)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, HashOneTimeToken(token), nil
+}
+
+// HashOneTimeToken hashes a presented one-time token for lookup.
+func HashOneTimeToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
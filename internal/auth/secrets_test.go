@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, prefix, hash, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(key, prefix+"_") {
+		t.Errorf("key %q does not start with prefix %q", key, prefix)
+	}
+
+	if !bytes.Equal(hash, HashAPIKey(key)) {
+		t.Error("hash does not match the generated key")
+	}
+
+	other, _, _, _ := GenerateAPIKey()
+	if other == key {
+		t.Error("expected distinct keys")
+	}
+}
+
+func TestGenerateOneTimeToken(t *testing.T) {
+	token, hash, err := GenerateOneTimeToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(token) < 40 {
+		t.Errorf("token %q is too short", token)
+	}
+
+	if !bytes.Equal(hash, HashOneTimeToken(token)) {
+		t.Error("hash does not match the generated token")
+	}
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+)
+
+// StaticToken is a preconfigured bearer token granting a fixed role to a
+// caller without a user account, such as an operator script.
+type StaticToken struct {
+	Name  string
+	Token string
+	Role  string
+}
+
+// ParseStaticTokens parses comma separated "name:token:role" entries.
+func ParseStaticTokens(s string) ([]StaticToken, error) {
+	var tokens []StaticToken
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("invalid static token entry %q, expected name:token:role", entry)
+		}
+
+		tokens = append(tokens, StaticToken{Name: parts[0], Token: parts[1], Role: parts[2]})
+	}
+
+	return tokens, nil
+}
+
+// LookupStaticToken finds the static token matching token, comparing in
+// constant time.
+func LookupStaticToken(tokens []StaticToken, token string) (StaticToken, bool) {
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Token), []byte(token)) == 1 {
+			return t, true
+		}
+	}
+	return StaticToken{}, false
+}
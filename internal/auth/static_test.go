@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStaticTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []StaticToken
+		wantErr bool
+	}{
+		{"empty", "", nil, false},
+		{"single", "kiosk:s3cret:admin", []StaticToken{{"kiosk", "s3cret", "admin"}}, false},
+		{"several with spaces", "kiosk:a:admin, pipeline:b:coach,", []StaticToken{{"kiosk", "a", "admin"}, {"pipeline", "b", "coach"}}, false},
+		{"missing role", "kiosk:s3cret", nil, true},
+		{"empty token", "kiosk::admin", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStaticTokens(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupStaticToken(t *testing.T) {
+	tokens := []StaticToken{{"kiosk", "a", "admin"}, {"pipeline", "b", "coach"}}
+
+	if got, ok := LookupStaticToken(tokens, "b"); !ok || got.Name != "pipeline" {
+		t.Errorf("got %v, %v want pipeline", got, ok)
+	}
+
+	if _, ok := LookupStaticToken(tokens, "c"); ok {
+		t.Error("expected unknown token to be rejected")
+	}
+}
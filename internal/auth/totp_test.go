@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+)
+
+func TestValidateTOTP(t *testing.T) {
+	enrollment, err := GenerateTOTP("mfit-test", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.HasPrefix(enrollment.QRCode, []byte("\x89PNG")) {
+		t.Error("expected a PNG QR code")
+	}
+
+	now := time.Unix(1_700_000_000, 0)
+	code, _ := totp.GenerateCode(enrollment.Secret, now)
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		wantStep int64
+		wantOK   bool
+	}{
+		{"same step", now, now.Unix() / totpPeriod, true},
+		{"previous step", now.Add(totpPeriod * time.Second), now.Unix() / totpPeriod, true},
+		{"too old", now.Add(3 * totpPeriod * time.Second), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTOTP(enrollment.Secret, code, tt.at)
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("got (%d, %v) want (%d, %v)", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := GenerateRecoveryCodes(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(codes) != 10 || len(codes[0]) != 11 {
+		t.Fatalf("unexpected codes %v", codes)
+	}
+
+	if !bytes.Equal(hashes[0], HashRecoveryCode(" "+codes[0][:5]+codes[0][6:])) {
+		t.Error("expected the hash to ignore dashes and spaces")
+	}
+}
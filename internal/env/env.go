@@ -3,6 +3,7 @@ package env
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 func GetString(key string, fallback string) string {
@@ -37,3 +38,27 @@ func GetBool(key string, fallback bool) bool {
 	}
 	return valBool
 }
+
+func GetDuration(key string, fallback time.Duration) time.Duration {
+	val, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	valDuration, err := time.ParseDuration(val)
+	if err != nil {
+		return fallback
+	}
+	return valDuration
+}
+
+func GetFloat(key string, fallback float64) float64 {
+	val, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	valFloat, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return fallback
+	}
+	return valFloat
+}
@@ -0,0 +1,108 @@
+// Package generator builds workout sessions from catalog workouts that fit a
+// time budget and cover the requested targets.
+package generator
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+)
+
+// DefaultDurationMinutes is used for workouts without a duration so that
+// they still count against the budget.
+const DefaultDurationMinutes = 5
+
+var difficultyRank = map[string]int{"beginner": 0, "intermediate": 1, "advanced": 2}
+
+type Candidate struct {
+	WorkoutID       int64
+	BodyPartID      int64
+	PrimaryTargetID int64
+	Difficulty      string
+	DurationMinutes int
+}
+
+type Options struct {
+	TimeBudgetMinutes int
+	// TargetIDs are covered first, in order, before the rest of the budget
+	// is filled with workouts for other primary targets
+	TargetIDs []int64
+	Seed      uint64
+}
+
+type Plan struct {
+	Workouts         []Candidate
+	TotalMinutes     int
+	CoveredTargetIDs []int64
+	MissingTargetIDs []int64
+}
+
+// Generate picks at most one workout per primary target, first for the
+// requested targets and then for any others while time remains. Ties are
+// broken by a shuffle seeded with opts.Seed, so the same candidates, options
+// and seed always give the same plan. Workouts for the same body part are
+// kept together in the plan, harder ones first.
+func Generate(candidates []Candidate, opts Options) Plan {
+	pool := slices.Clone(candidates)
+	slices.SortFunc(pool, func(a, b Candidate) int { return cmp.Compare(a.WorkoutID, b.WorkoutID) })
+
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed))
+	rng.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+
+	plan := Plan{Workouts: []Candidate{}, CoveredTargetIDs: []int64{}, MissingTargetIDs: []int64{}}
+	covered := make(map[int64]bool)
+
+	pick := func(accept func(Candidate) bool) bool {
+		for _, c := range pool {
+			if covered[c.PrimaryTargetID] || !accept(c) {
+				continue
+			}
+			if plan.TotalMinutes+duration(c) > opts.TimeBudgetMinutes {
+				continue
+			}
+
+			covered[c.PrimaryTargetID] = true
+			plan.Workouts = append(plan.Workouts, c)
+			plan.TotalMinutes += duration(c)
+			plan.CoveredTargetIDs = append(plan.CoveredTargetIDs, c.PrimaryTargetID)
+			return true
+		}
+		return false
+	}
+
+	for _, target := range opts.TargetIDs {
+		if covered[target] {
+			continue
+		}
+		if !pick(func(c Candidate) bool { return c.PrimaryTargetID == target }) {
+			plan.MissingTargetIDs = append(plan.MissingTargetIDs, target)
+		}
+	}
+
+	for pick(func(Candidate) bool { return true }) {
+	}
+
+	// first position of each body part keeps groups in selection order
+	groups := make(map[int64]int)
+	for i, c := range plan.Workouts {
+		if _, ok := groups[c.BodyPartID]; !ok {
+			groups[c.BodyPartID] = i
+		}
+	}
+
+	slices.SortStableFunc(plan.Workouts, func(a, b Candidate) int {
+		return cmp.Or(
+			cmp.Compare(groups[a.BodyPartID], groups[b.BodyPartID]),
+			cmp.Compare(difficultyRank[b.Difficulty], difficultyRank[a.Difficulty]),
+		)
+	})
+
+	return plan
+}
+
+func duration(c Candidate) int {
+	if c.DurationMinutes <= 0 {
+		return DefaultDurationMinutes
+	}
+	return c.DurationMinutes
+}
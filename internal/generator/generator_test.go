@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// catalog has two workouts for each of targets 1 to 4 and one for target 5.
+var catalog = []Candidate{
+	{WorkoutID: 1, BodyPartID: 1, PrimaryTargetID: 1, Difficulty: "beginner", DurationMinutes: 10},
+	{WorkoutID: 2, BodyPartID: 1, PrimaryTargetID: 1, Difficulty: "advanced", DurationMinutes: 10},
+	{WorkoutID: 3, BodyPartID: 1, PrimaryTargetID: 2, Difficulty: "intermediate", DurationMinutes: 10},
+	{WorkoutID: 4, BodyPartID: 1, PrimaryTargetID: 2, Difficulty: "beginner", DurationMinutes: 15},
+	{WorkoutID: 5, BodyPartID: 2, PrimaryTargetID: 3, Difficulty: "advanced", DurationMinutes: 10},
+	{WorkoutID: 6, BodyPartID: 2, PrimaryTargetID: 3, Difficulty: "beginner"},
+	{WorkoutID: 7, BodyPartID: 2, PrimaryTargetID: 4, Difficulty: "intermediate", DurationMinutes: 20},
+	{WorkoutID: 8, BodyPartID: 2, PrimaryTargetID: 4, Difficulty: "beginner", DurationMinutes: 5},
+	{WorkoutID: 9, BodyPartID: 3, PrimaryTargetID: 5, Difficulty: "beginner", DurationMinutes: 30},
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("same seed gives the same plan", func(t *testing.T) {
+		opts := Options{TimeBudgetMinutes: 45, Seed: 42}
+
+		first := Generate(catalog, opts)
+
+		// candidate order must not matter either
+		reversed := make([]Candidate, len(catalog))
+		for i, c := range catalog {
+			reversed[len(catalog)-1-i] = c
+		}
+		second := Generate(reversed, opts)
+
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("got %+v then %+v", first, second)
+		}
+	})
+
+	t.Run("different seeds vary the plan", func(t *testing.T) {
+		plans := make(map[string]bool)
+		for seed := uint64(0); seed < 10; seed++ {
+			plan := Generate(catalog, Options{TimeBudgetMinutes: 45, Seed: seed})
+			plans[fmt.Sprint(plan.Workouts)] = true
+		}
+
+		if len(plans) < 2 {
+			t.Error("expected different seeds to give different plans")
+		}
+	})
+
+	t.Run("requested targets come first without duplicates", func(t *testing.T) {
+		for seed := uint64(0); seed < 20; seed++ {
+			plan := Generate(catalog, Options{TimeBudgetMinutes: 40, TargetIDs: []int64{4, 2}, Seed: seed})
+
+			if plan.TotalMinutes > 40 {
+				t.Errorf("seed %d: %d minutes over the budget", seed, plan.TotalMinutes)
+			}
+
+			if len(plan.CoveredTargetIDs) < 2 || plan.CoveredTargetIDs[0] != 4 || plan.CoveredTargetIDs[1] != 2 {
+				t.Errorf("seed %d: requested targets not covered first: %v", seed, plan.CoveredTargetIDs)
+			}
+
+			seen := make(map[int64]bool)
+			for _, c := range plan.Workouts {
+				if seen[c.PrimaryTargetID] {
+					t.Errorf("seed %d: target %d covered twice", seed, c.PrimaryTargetID)
+				}
+				seen[c.PrimaryTargetID] = true
+			}
+
+			for i := 1; i < len(plan.Workouts); i++ {
+				prev, cur := plan.Workouts[i-1], plan.Workouts[i]
+				if prev.BodyPartID == cur.BodyPartID && difficultyRank[prev.Difficulty] < difficultyRank[cur.Difficulty] {
+					t.Errorf("seed %d: %s before %s in the same body part", seed, prev.Difficulty, cur.Difficulty)
+				}
+			}
+		}
+	})
+
+	t.Run("targets that do not fit are reported missing", func(t *testing.T) {
+		plan := Generate(catalog, Options{TimeBudgetMinutes: 20, TargetIDs: []int64{5, 6}, Seed: 1})
+
+		if !reflect.DeepEqual(plan.MissingTargetIDs, []int64{5, 6}) {
+			t.Errorf("got missing %v want [5 6]", plan.MissingTargetIDs)
+		}
+	})
+
+	t.Run("workouts without a duration use the default", func(t *testing.T) {
+		plan := Generate(catalog[5:6], Options{TimeBudgetMinutes: 60})
+
+		if plan.TotalMinutes != DefaultDurationMinutes {
+			t.Errorf("got %d minutes want %d", plan.TotalMinutes, DefaultDurationMinutes)
+		}
+	})
+}
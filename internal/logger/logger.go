@@ -1,12 +1,143 @@
 package logger
 
-import "go.uber.org/zap"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var ErrFixedLevel = errors.New("logger level cannot be changed")
+
+// Config selects how the logger encodes and where it writes. Development
+// switches to zap's development defaults: console output, caller stack
+// traces on warnings and panics on DPanic. An empty Encoding keeps the
+// encoding of the chosen defaults.
+type Config struct {
+	Level       string
+	Encoding    string
+	Development bool
+	Sampling    bool
+	OutputPaths []string
+	Fields      map[string]string
+}
+
+// DefaultConfig is the production logger NewLogger used to hardcode.
+func DefaultConfig() Config {
+	return Config{
+		Level:       "info",
+		Encoding:    "json",
+		Sampling:    true,
+		OutputPaths: []string{"stderr"},
+	}
+}
 
 type Logger struct {
 	*zap.SugaredLogger
+	level *zap.AtomicLevel
+}
+
+func NewLogger(cfg Config) (Logger, error) {
+	level, err := zap.ParseAtomicLevel(cfg.Level)
+	if err != nil {
+		return Logger{}, err
+	}
+
+	zc := zap.NewProductionConfig()
+	if cfg.Development {
+		zc = zap.NewDevelopmentConfig()
+	}
+
+	zc.Level = level
+	if cfg.Encoding != "" {
+		zc.Encoding = cfg.Encoding
+	}
+	if !cfg.Sampling {
+		zc.Sampling = nil
+	} else if zc.Sampling == nil {
+		zc.Sampling = &zap.SamplingConfig{Initial: 100, Thereafter: 100}
+	}
+	if len(cfg.OutputPaths) > 0 {
+		zc.OutputPaths = cfg.OutputPaths
+	}
+
+	zc.InitialFields = make(map[string]interface{}, len(cfg.Fields))
+	for k, v := range cfg.Fields {
+		zc.InitialFields[k] = v
+	}
+
+	zl, err := zc.Build()
+	if err != nil {
+		return Logger{}, err
+	}
+
+	return Logger{zl.Sugar(), &level}, nil
+}
+
+// ParseFields parses comma separated "key=value" entries into static fields.
+func ParseFields(s string) (map[string]string, error) {
+	fields := make(map[string]string)
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid log field entry %q, expected key=value", entry)
+		}
+
+		fields[key] = value
+	}
+
+	return fields, nil
+}
+
+// Level is the minimum level currently logged.
+func (l Logger) Level() string {
+	if l.level == nil {
+		return l.SugaredLogger.Level().String()
+	}
+	return l.level.String()
+}
+
+// SetLevel changes the minimum level of l and every logger derived from it
+// while the service runs.
+func (l Logger) SetLevel(level string) error {
+	if l.level == nil {
+		return ErrFixedLevel
+	}
+
+	lvl, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	l.level.SetLevel(lvl)
+	return nil
+}
+
+// With returns a child logger that adds the given key value pairs to every
+// entry. It shares the level of l.
+func (l Logger) With(args ...interface{}) Logger {
+	return Logger{l.SugaredLogger.With(args...), l.level}
+}
+
+type contextKey struct{}
+
+// WithContext returns a copy of ctx carrying l, usually a logger tagged with
+// the ID of the request ctx belongs to.
+func WithContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
 }
 
-func NewLogger() Logger {
-	zl := zap.Must(zap.NewProduction()).Sugar()
-	return Logger{zl}
+// FromContext returns the logger attached to ctx by WithContext.
+func FromContext(ctx context.Context) (Logger, bool) {
+	l, ok := ctx.Value(contextKey{}).(Logger)
+	return l, ok
 }
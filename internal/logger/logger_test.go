@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"empty", "", map[string]string{}, false},
+		{"several", "service=mfit-api, version=1.2.0", map[string]string{"service": "mfit-api", "version": "1.2.0"}, false},
+		{"empty value", "region=", map[string]string{"region": ""}, false},
+		{"missing separator", "service", nil, true},
+		{"missing key", "=mfit-api", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFields(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v want error %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLogger(t *testing.T) {
+	t.Run("rejects unknown levels", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Level = "verbose"
+
+		if _, err := NewLogger(cfg); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("rejects unknown encodings", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Encoding = "xml"
+
+		if _, err := NewLogger(cfg); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("builds console development loggers", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Encoding = "console"
+		cfg.Development = true
+		cfg.Sampling = false
+		cfg.Level = "debug"
+
+		l, err := NewLogger(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if l.Level() != "debug" {
+			t.Errorf("got level %q want %q", l.Level(), "debug")
+		}
+	})
+}
+
+func TestSetLevel(t *testing.T) {
+	l, logs := NewTestLogger()
+	child := l.With("request_id", "test-request")
+
+	if err := l.SetLevel("warn"); err != nil {
+		t.Fatal(err)
+	}
+
+	child.Info("dropped")
+	child.Warn("kept")
+
+	entries := logs.All()
+	if len(entries) != 1 || entries[0].Message != "kept" {
+		t.Fatalf("got entries %+v want only the warning", entries)
+	}
+
+	if err := l.SetLevel("verbose"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+
+	if err := (Logger{}).SetLevel("info"); err != ErrFixedLevel {
+		t.Errorf("got %v want %v", err, ErrFixedLevel)
+	}
+}
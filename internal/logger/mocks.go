@@ -0,0 +1,16 @@
+package logger
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// NewTestLogger returns a debug level logger that records its entries in
+// memory instead of writing them, so tests can assert on what was logged.
+func NewTestLogger() (Logger, *observer.ObservedLogs) {
+	level := zap.NewAtomicLevelAt(zapcore.DebugLevel)
+	core, logs := observer.New(level)
+
+	return Logger{zap.New(core).Sugar(), &level}, logs
+}
@@ -0,0 +1,69 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// FileMailer logs every message and, when dir is set, writes it to a file
+// there instead of delivering it. It is meant for local development and
+// tests.
+type FileMailer struct {
+	dir    string
+	logger *zap.SugaredLogger
+
+	mu   sync.Mutex
+	sent []Message
+}
+
+func NewFileMailer(dir string, logger *zap.SugaredLogger) (*FileMailer, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+
+	return &FileMailer{dir: dir, logger: logger}, nil
+}
+
+func (m *FileMailer) Send(templateFile, username, email string, data any) error {
+	msg, err := render(templateFile, email, data)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sent = append(m.sent, *msg)
+
+	if m.dir == "" {
+		m.logger.Infow("email not delivered", "to", email, "subject", msg.Subject, "body", msg.Text)
+		return nil
+	}
+
+	name := fmt.Sprintf("%d-%s.txt", time.Now().UnixNano(), strings.ReplaceAll(email, "@", "_at_"))
+	path := filepath.Join(m.dir, name)
+
+	content := fmt.Sprintf("To: %s\nSubject: %s\n\n%s\n\n%s", msg.To, msg.Subject, msg.Text, msg.HTML)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return err
+	}
+
+	m.logger.Infow("email written to file", "to", email, "subject", msg.Subject, "path", path)
+	return nil
+}
+
+// Sent returns the messages sent so far, oldest first.
+func (m *FileMailer) Sent() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Message(nil), m.sent...)
+}
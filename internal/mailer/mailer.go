@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	"text/template"
+)
+
+const (
+	FromName              = "MFit"
+	UserWelcomeTemplate   = "user_invitation.tmpl"
+	PasswordResetTemplate = "password_reset.tmpl"
+)
+
+//go:embed "templates"
+var FS embed.FS
+
+// Client sends templated emails. Templates live in the templates directory
+// and define "subject", "plainBody" and "htmlBody" blocks.
+type Client interface {
+	Send(templateFile, username, email string, data any) error
+}
+
+// Message is a rendered email ready to be delivered.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+func render(templateFile, email string, data any) (*Message, error) {
+	path := "templates/" + templateFile
+
+	tmpl, err := template.ParseFS(FS, path)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := new(bytes.Buffer)
+	if err := tmpl.ExecuteTemplate(subject, "subject", data); err != nil {
+		return nil, err
+	}
+
+	text := new(bytes.Buffer)
+	if err := tmpl.ExecuteTemplate(text, "plainBody", data); err != nil {
+		return nil, err
+	}
+
+	htmlTmpl, err := htmltemplate.ParseFS(FS, path)
+	if err != nil {
+		return nil, err
+	}
+
+	html := new(bytes.Buffer)
+	if err := htmlTmpl.ExecuteTemplate(html, "htmlBody", data); err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		To:      email,
+		Subject: subject.String(),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
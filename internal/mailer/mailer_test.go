@@ -0,0 +1,78 @@
+package mailer
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestRender(t *testing.T) {
+	data := struct {
+		Username      string
+		ActivationURL string
+		ExpiresIn     string
+	}{"<jo>", "https://mfit.example/activate?token=abc", "72h0m0s"}
+
+	msg, err := render(UserWelcomeTemplate, "jo@example.com", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Subject != "Activate your MFit account" {
+		t.Errorf("got subject %q", msg.Subject)
+	}
+
+	if !strings.Contains(msg.Text, "Hi <jo>,") || !strings.Contains(msg.Text, data.ActivationURL) {
+		t.Errorf("text body is missing data: %q", msg.Text)
+	}
+
+	if !strings.Contains(msg.HTML, "Hi &lt;jo&gt;,") {
+		t.Errorf("html body is not escaped: %q", msg.HTML)
+	}
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := t.TempDir()
+
+	m, err := NewFileMailer(dir, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := map[string]string{"Username": "jo", "ResetURL": "https://mfit.example/reset?token=abc", "ExpiresIn": "1h0m0s"}
+	if err := m.Send(PasswordResetTemplate, "jo", "jo@example.com", data); err != nil {
+		t.Fatal(err)
+	}
+
+	sent := m.Sent()
+	if len(sent) != 1 || sent[0].To != "jo@example.com" {
+		t.Fatalf("got %v want one message to jo@example.com", sent)
+	}
+
+	files, _ := os.ReadDir(dir)
+	if len(files) != 1 {
+		t.Fatalf("got %d files want 1", len(files))
+	}
+
+	content, _ := os.ReadFile(dir + "/" + files[0].Name())
+	if !strings.Contains(string(content), data["ResetURL"]) {
+		t.Errorf("file is missing the reset link: %s", content)
+	}
+}
+
+func TestSMTPEncode(t *testing.T) {
+	m := NewSMTPMailer("localhost", 25, "", "", "noreply@mfit.example")
+
+	body, err := m.encode(&Message{To: "jo@example.com", Subject: "Hi", Text: "plain", HTML: "<p>html</p>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"To: jo@example.com", "multipart/alternative", "text/plain", "text/html", "<p>html</p>"} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("message is missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+const maxRetries = 3
+
+type SMTPMailer struct {
+	addr      string
+	auth      smtp.Auth
+	fromEmail string
+}
+
+func NewSMTPMailer(host string, port int, username, password, fromEmail string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPMailer{
+		addr:      net.JoinHostPort(host, strconv.Itoa(port)),
+		auth:      auth,
+		fromEmail: fromEmail,
+	}
+}
+
+func (m *SMTPMailer) Send(templateFile, username, email string, data any) error {
+	msg, err := render(templateFile, email, data)
+	if err != nil {
+		return err
+	}
+
+	body, err := m.encode(msg)
+	if err != nil {
+		return err
+	}
+
+	for i := range maxRetries {
+		err = smtp.SendMail(m.addr, m.auth, m.fromEmail, []string{email}, body)
+		if err == nil {
+			return nil
+		}
+
+		// back off a little longer after each failed attempt
+		time.Sleep(time.Second * time.Duration(i+1))
+	}
+
+	return fmt.Errorf("failed to send email after %d attempts: %w", maxRetries, err)
+}
+
+// encode builds a multipart/alternative message carrying both bodies.
+func (m *SMTPMailer) encode(msg *Message) ([]byte, error) {
+	boundary, err := newBoundary()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "From: %s\r\n", mime.QEncoding.Encode("utf-8", FromName)+" <"+m.fromEmail+">")
+	fmt.Fprintf(buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain", msg.Text},
+		{"text/html", msg.HTML},
+	} {
+		fmt.Fprintf(buf, "--%s\r\n", boundary)
+		fmt.Fprintf(buf, "Content-Type: %s; charset=utf-8\r\n", part.contentType)
+		buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+		qp := quotedprintable.NewWriter(buf)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+		buf.WriteString("\r\n")
+	}
+	fmt.Fprintf(buf, "--%s--\r\n", boundary)
+
+	return buf.Bytes(), nil
+}
+
+func newBoundary() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "mfit"
+
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests served, by method, route pattern and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Time taken to serve HTTP requests, by method and route pattern.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	HTTPInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "HTTP requests currently being served.",
+	})
+
+	StoreQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "store",
+		Name:      "query_duration_seconds",
+		Help:      "Time taken by database statements and transactions, by store and operation.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"store", "operation"})
+
+	StoreQueryErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "store",
+		Name:      "query_errors_total",
+		Help:      "Database statements and transactions that failed, by store and operation.",
+	}, []string{"store", "operation"})
+
+	WorkoutsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "workouts_created_total",
+		Help:      "Workouts added to the catalog.",
+	})
+
+	UsersRegistered = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "users_registered_total",
+		Help:      "Accounts registered, activated or not.",
+	})
+
+	RoutinesCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "routines_created_total",
+		Help:      "Routines created by users.",
+	})
+
+	SessionsStarted = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sessions_started_total",
+		Help:      "Training sessions started by users.",
+	})
+
+	SetsLogged = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "session_sets_logged_total",
+		Help:      "Sets logged against training sessions.",
+	})
+)
+
+// RegisterDB exports the sql.DBStats of the connection pool db.
+func RegisterDB(db *sql.DB, name string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves every registered metric in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
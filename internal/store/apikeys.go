@@ -0,0 +1,215 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var ErrAPIKeyRevoked = errors.New("api key is revoked")
+
+const (
+	ScopeCatalogRead  = "catalog:read"
+	ScopeCatalogWrite = "catalog:write"
+	ScopeMetricsRead  = "metrics:read"
+)
+
+type APIKeyStore struct {
+	db *storeDB
+}
+
+type APIKey struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Hash       []byte     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  *int64     `json:"created_by"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  string     `json:"created_at"`
+}
+
+// Active reports whether the key may still be used at the given time.
+func (k *APIKey) Active(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+const apiKeyColumns = `id, name, prefix, key_hash, scopes, created_by, expires_at, revoked_at, last_used_at, created_at`
+
+func scanAPIKey(row interface{ Scan(...any) error }, key *APIKey) error {
+	return row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&key.Hash,
+		pq.Array(&key.Scopes),
+		&key.CreatedBy,
+		&key.ExpiresAt,
+		&key.RevokedAt,
+		&key.LastUsedAt,
+		&key.CreatedAt,
+	)
+}
+
+func (s *APIKeyStore) Create(ctx context.Context, key *APIKey) error {
+	query := `
+    INSERT INTO api_keys (name, prefix, key_hash, scopes, created_by, expires_at)
+    VALUES ($1, $2, $3, $4, $5, $6)
+    RETURNING id, created_at
+    ;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	err := s.db.QueryRowContext(
+		ctx,
+		query,
+		key.Name,
+		key.Prefix,
+		key.Hash,
+		pq.Array(key.Scopes),
+		key.CreatedBy,
+		key.ExpiresAt,
+	).Scan(&key.ID, &key.CreatedAt)
+	return translateError(err)
+}
+
+func (s *APIKeyStore) GetByID(ctx context.Context, id int64) (*APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var key APIKey
+	if err := scanAPIKey(s.db.QueryRowContext(ctx, query, id), &key); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &key, nil
+}
+
+func (s *APIKeyStore) GetByHash(ctx context.Context, hash []byte) (*APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var key APIKey
+	if err := scanAPIKey(s.db.QueryRowContext(ctx, query, hash), &key); err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &key, nil
+}
+
+func (s *APIKeyStore) GetAll(ctx context.Context) ([]APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY id;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []APIKey{}
+	for rows.Next() {
+		var key APIKey
+		if err := scanAPIKey(rows, &key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// UpdateExpiry changes when a key expires. Revoked keys are left alone and
+// reported as ErrAPIKeyRevoked.
+func (s *APIKeyStore) UpdateExpiry(ctx context.Context, key *APIKey) error {
+	query := `UPDATE api_keys SET expires_at = $1 WHERE id = $2 AND revoked_at IS NULL;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, key.ExpiresAt, key.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		var exists bool
+		if err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM api_keys WHERE id = $1);`, key.ID).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return ErrAPIKeyRevoked
+		}
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Revoke disables a key while keeping it listed for auditing. Revoking a key
+// twice returns ErrNotFound.
+func (s *APIKeyStore) Revoke(ctx context.Context, id int64) error {
+	query := `UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Touch records that a key was used. Writes are coalesced to at most one per
+// minute per key so busy integrations don't contend on the row.
+func (s *APIKeyStore) Touch(ctx context.Context, id int64) error {
+	query := `
+    UPDATE api_keys SET last_used_at = NOW()
+    WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+    ;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := s.db.ExecContext(ctx, query, id)
+	return err
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+var AutocompleteTypes = []string{"workout", "target", "equipment", "bodypart"}
+
+type AutocompleteStore struct {
+	db *storeDB
+}
+
+type Suggestion struct {
+	Type  string  `json:"type"`
+	ID    int64   `json:"id"`
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+// Suggest returns catalog names resembling the query, ranked by trigram
+// similarity with prefix matches first. Each branch filters with the trigram
+// operators so that the name_trgm indexes are used.
+func (s *AutocompleteStore) Suggest(ctx context.Context, aq AutocompleteQuery) ([]Suggestion, error) {
+	query := `
+    SELECT type, id, name, score
+    FROM (
+        SELECT 'workout' AS type, id, name FROM workout
+        WHERE 'workout' = ANY($2) AND ($1 <% name OR name % $1)
+        UNION ALL
+        SELECT 'target', id, name FROM target
+        WHERE 'target' = ANY($2) AND ($1 <% name OR name % $1)
+        UNION ALL
+        SELECT 'equipment', id, name FROM equipment
+        WHERE 'equipment' = ANY($2) AND ($1 <% name OR name % $1)
+        UNION ALL
+        SELECT 'bodypart', id, name FROM body_part
+        WHERE 'bodypart' = ANY($2) AND ($1 <% name OR name % $1)
+    ) candidates
+    CROSS JOIN LATERAL (
+        SELECT GREATEST(similarity(name, $1), word_similarity($1, name))
+            + CASE WHEN starts_with(lower(name), lower($1)) THEN 1 ELSE 0 END AS score
+    ) ranking
+    ORDER BY score DESC, length(name), name
+    LIMIT $3
+    ;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, aq.Query, pq.Array(aq.Types), aq.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var suggestions []Suggestion
+	for rows.Next() {
+		var sg Suggestion
+		if err := rows.Scan(&sg.Type, &sg.ID, &sg.Name, &sg.Score); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, sg)
+	}
+
+	return suggestions, rows.Err()
+}
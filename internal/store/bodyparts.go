@@ -3,12 +3,11 @@ package store
 import (
 	"context"
 	"database/sql"
-
-	"github.com/lib/pq"
+	"fmt"
 )
 
 type BodyPartStore struct {
-	db *sql.DB
+	db *storeDB
 }
 
 type BodyPart struct {
@@ -24,44 +23,67 @@ func (s *BodyPartStore) Create(ctx context.Context, bodyPart *BodyPart) error {
 	defer cancel()
 
 	if err := s.db.QueryRowContext(ctx, query, &bodyPart.Name, &bodyPart.ImageUrl).Scan(&bodyPart.ID); err != nil {
-		// check unique constraints validation
-		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23505" {
-			return ErrDuplicate
-		}
-		return err
+		return translateError(err)
 
 	}
 
 	return nil
 }
 
-func (s *BodyPartStore) GetAll(ctx context.Context) ([]BodyPart, error) {
-	query := `SELECT id, name, image_url FROM body_part;`
+func (s *BodyPartStore) GetAll(ctx context.Context, page PaginatedQuery) ([]BodyPart, PageMeta, error) {
+	ks, err := page.keyset(BodyPartSorts, "b.id", 0)
+	if err != nil {
+		return nil, PageMeta{}, err
+	}
+
+	query := fmt.Sprintf(`
+    SELECT %s, b.id, b.name, b.image_url
+    FROM body_part b
+    WHERE %s
+    ORDER BY %s
+    LIMIT %d
+    ;`, ks.sortKey, ks.where, ks.orderBy, ks.limit)
+
+	countQuery := `SELECT COUNT(*) FROM body_part;`
 
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
 	defer cancel()
 
-	rows, err := s.db.QueryContext(ctx, query)
+	var total int
+	if err := s.db.QueryRowContext(ctx, countQuery).Scan(&total); err != nil {
+		return nil, PageMeta{}, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, ks.args...)
 	if err != nil {
-		return nil, err
+		return nil, PageMeta{}, err
 	}
 	defer rows.Close()
 
-	var bodyParts []BodyPart
+	bodyParts := []BodyPart{}
+	var positions []cursor
 	for rows.Next() {
 		var b BodyPart
+		var c cursor
 		err := rows.Scan(
+			&c.Key,
 			&b.ID,
 			&b.Name,
 			&b.ImageUrl,
 		)
 		if err != nil {
-			return nil, err
+			return nil, PageMeta{}, err
 		}
+		c.ID = b.ID
 		bodyParts = append(bodyParts, b)
+		positions = append(positions, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, PageMeta{}, err
 	}
 
-	return bodyParts, nil
+	bodyParts, meta := paginate(page, ks, bodyParts, positions, total)
+	return bodyParts, meta, nil
 }
 
 func (s *BodyPartStore) GetByID(ctx context.Context, id int64) (*BodyPart, error) {
@@ -115,7 +137,7 @@ func (s *BodyPartStore) Update(ctx context.Context, bodyPart *BodyPart) error {
 
 	res, err := s.db.ExecContext(ctx, query, bodyPart.Name, bodyPart.ImageUrl, bodyPart.ID)
 	if err != nil {
-		return err
+		return translateError(err)
 	}
 
 	rowsAffected, err := res.RowsAffected()
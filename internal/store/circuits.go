@@ -0,0 +1,378 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+const (
+	PhaseWork      = "work"
+	PhaseRest      = "rest"
+	PhaseRoundRest = "round_rest"
+)
+
+type CircuitStore struct {
+	db *storeDB
+}
+
+type Circuit struct {
+	ID               int64           `json:"id"`
+	UserID           int64           `json:"user_id"`
+	Name             string          `json:"name"`
+	Kind             string          `json:"kind"`
+	Rounds           int             `json:"rounds"`
+	WorkSeconds      int             `json:"work_seconds"`
+	RestSeconds      int             `json:"rest_seconds"`
+	RoundRestSeconds int             `json:"round_rest_seconds"`
+	Members          []CircuitMember `json:"members"`
+	CreatedAt        string          `json:"created_at"`
+	UpdatedAt        string          `json:"updated_at"`
+}
+
+type CircuitMember struct {
+	ID        int64 `json:"id"`
+	Position  int   `json:"position"`
+	WorkoutID int64 `json:"workout_id"`
+	// WorkSeconds overrides the circuit's work interval for this member
+	WorkSeconds *int                `json:"work_seconds"`
+	Workout     *PresentableWorkout `json:"workout"`
+}
+
+// CircuitPhase is one interval of a circuit's timeline. Rest phases carry the
+// member that follows them so clients can announce what is next.
+type CircuitPhase struct {
+	Phase           string `json:"phase"`
+	Round           int    `json:"round"`
+	Position        int    `json:"position"`
+	WorkoutID       int64  `json:"workout_id"`
+	WorkoutName     string `json:"workout_name"`
+	StartSecond     int    `json:"start_second"`
+	DurationSeconds int    `json:"duration_seconds"`
+}
+
+// Timeline expands the circuit into consecutive work and rest phases, with
+// start offsets in seconds from the start of the first round. Zero-length
+// rests are left out and the circuit does not end on a rest.
+func (c *Circuit) Timeline() []CircuitPhase {
+	phases := []CircuitPhase{}
+	second := 0
+
+	add := func(phase string, round int, member CircuitMember, duration int) {
+		if duration <= 0 {
+			return
+		}
+
+		p := CircuitPhase{
+			Phase:           phase,
+			Round:           round,
+			Position:        member.Position,
+			WorkoutID:       member.WorkoutID,
+			StartSecond:     second,
+			DurationSeconds: duration,
+		}
+		if member.Workout != nil {
+			p.WorkoutName = member.Workout.Name
+		}
+
+		phases = append(phases, p)
+		second += duration
+	}
+
+	for round := 1; round <= c.Rounds; round++ {
+		for i, member := range c.Members {
+			if i > 0 {
+				add(PhaseRest, round, member, c.RestSeconds)
+			} else if round > 1 {
+				add(PhaseRoundRest, round, member, c.RoundRestSeconds)
+			}
+
+			work := c.WorkSeconds
+			if member.WorkSeconds != nil {
+				work = *member.WorkSeconds
+			}
+			add(PhaseWork, round, member, work)
+		}
+	}
+
+	return phases
+}
+
+func (s *CircuitStore) Create(ctx context.Context, circuit *Circuit) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		query := `
+        INSERT INTO circuit (user_id, name, kind, rounds, work_seconds, rest_seconds, round_rest_seconds)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, created_at, updated_at
+        ;`
+
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+		defer cancel()
+
+		err := tx.QueryRowContext(
+			ctx,
+			query,
+			circuit.UserID,
+			circuit.Name,
+			circuit.Kind,
+			circuit.Rounds,
+			circuit.WorkSeconds,
+			circuit.RestSeconds,
+			circuit.RoundRestSeconds,
+		).Scan(
+			&circuit.ID,
+			&circuit.CreatedAt,
+			&circuit.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		return s.insertMembers(ctx, tx, circuit)
+	})
+}
+
+// Update saves the circuit's settings and replaces its members.
+func (s *CircuitStore) Update(ctx context.Context, circuit *Circuit) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		query := `
+        UPDATE circuit
+        SET name = $1, kind = $2, rounds = $3, work_seconds = $4, rest_seconds = $5, round_rest_seconds = $6, updated_at = NOW()
+        WHERE id = $7
+        RETURNING updated_at
+        ;`
+
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+		defer cancel()
+
+		err := tx.QueryRowContext(
+			ctx,
+			query,
+			circuit.Name,
+			circuit.Kind,
+			circuit.Rounds,
+			circuit.WorkSeconds,
+			circuit.RestSeconds,
+			circuit.RoundRestSeconds,
+			circuit.ID,
+		).Scan(&circuit.UpdatedAt)
+		if err != nil {
+			switch err {
+			case sql.ErrNoRows:
+				return ErrNotFound
+			default:
+				return err
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM circuit_member WHERE circuit_id = $1;`, circuit.ID); err != nil {
+			return err
+		}
+
+		return s.insertMembers(ctx, tx, circuit)
+	})
+}
+
+func (s *CircuitStore) insertMembers(ctx context.Context, tx *sql.Tx, circuit *Circuit) error {
+	query := `
+    INSERT INTO circuit_member (circuit_id, workout_id, position, work_seconds)
+    VALUES ($1, $2, $3, $4)
+    RETURNING id
+    ;`
+
+	for i := range circuit.Members {
+		member := &circuit.Members[i]
+		member.Position = i + 1
+
+		err := tx.QueryRowContext(ctx, query, circuit.ID, member.WorkoutID, member.Position, member.WorkSeconds).Scan(&member.ID)
+		if err != nil {
+			// foreign key violation on workout_id
+			err = translateError(err)
+			if errors.Is(err, ErrForeignKey) {
+				return ErrUnknownWorkout
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *CircuitStore) GetByID(ctx context.Context, id int64) (*Circuit, error) {
+	query := `
+    SELECT id, user_id, name, kind, rounds, work_seconds, rest_seconds, round_rest_seconds, created_at, updated_at
+    FROM circuit
+    WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var circuit Circuit
+	err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&circuit.ID,
+		&circuit.UserID,
+		&circuit.Name,
+		&circuit.Kind,
+		&circuit.Rounds,
+		&circuit.WorkSeconds,
+		&circuit.RestSeconds,
+		&circuit.RoundRestSeconds,
+		&circuit.CreatedAt,
+		&circuit.UpdatedAt,
+	)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	circuits := []Circuit{circuit}
+	if err := s.loadMembers(ctx, circuits); err != nil {
+		return nil, err
+	}
+
+	return &circuits[0], nil
+}
+
+func (s *CircuitStore) GetAll(ctx context.Context) ([]Circuit, error) {
+	query := `
+    SELECT id, user_id, name, kind, rounds, work_seconds, rest_seconds, round_rest_seconds, created_at, updated_at
+    FROM circuit
+    ORDER BY id`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	circuits := []Circuit{}
+	for rows.Next() {
+		var circuit Circuit
+		err := rows.Scan(
+			&circuit.ID,
+			&circuit.UserID,
+			&circuit.Name,
+			&circuit.Kind,
+			&circuit.Rounds,
+			&circuit.WorkSeconds,
+			&circuit.RestSeconds,
+			&circuit.RoundRestSeconds,
+			&circuit.CreatedAt,
+			&circuit.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		circuits = append(circuits, circuit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadMembers(ctx, circuits); err != nil {
+		return nil, err
+	}
+
+	return circuits, nil
+}
+
+// loadMembers attaches the members of every circuit, each expanded with its
+// workout, using one query for members and one for workouts.
+func (s *CircuitStore) loadMembers(ctx context.Context, circuits []Circuit) error {
+	if len(circuits) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(circuits))
+	for i := range circuits {
+		ids[i] = circuits[i].ID
+	}
+
+	query := `
+    SELECT id, circuit_id, position, workout_id, work_seconds
+    FROM circuit_member
+    WHERE circuit_id = ANY($1)
+    ORDER BY circuit_id, position`
+
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	members := make(map[int64][]CircuitMember)
+	var workoutIDs []int64
+	for rows.Next() {
+		var member CircuitMember
+		var circuitID int64
+		err := rows.Scan(
+			&member.ID,
+			&circuitID,
+			&member.Position,
+			&member.WorkoutID,
+			&member.WorkSeconds,
+		)
+		if err != nil {
+			return err
+		}
+		members[circuitID] = append(members[circuitID], member)
+		workoutIDs = append(workoutIDs, member.WorkoutID)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	workouts, err := getWorkoutsByIDs(s.db, ctx, workoutIDs)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[int64]*PresentableWorkout, len(workouts))
+	for i := range workouts {
+		byID[workouts[i].ID] = &workouts[i]
+	}
+
+	for i := range circuits {
+		circuits[i].Members = members[circuits[i].ID]
+		if circuits[i].Members == nil {
+			circuits[i].Members = []CircuitMember{}
+		}
+
+		for j := range circuits[i].Members {
+			circuits[i].Members[j].Workout = byID[circuits[i].Members[j].WorkoutID]
+		}
+	}
+
+	return nil
+}
+
+func (s *CircuitStore) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM circuit WHERE id = $1;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCircuitTimeline(t *testing.T) {
+	twenty := 20
+	squat := &PresentableWorkout{Workout: Workout{ID: 1, Name: "Squat"}}
+	press := &PresentableWorkout{Workout: Workout{ID: 2, Name: "Press"}}
+
+	tests := []struct {
+		name    string
+		circuit Circuit
+		want    []CircuitPhase
+	}{
+		{
+			"no members",
+			Circuit{Rounds: 3, WorkSeconds: 30},
+			[]CircuitPhase{},
+		},
+		{
+			"rests between members and rounds",
+			Circuit{
+				Rounds:           2,
+				WorkSeconds:      40,
+				RestSeconds:      15,
+				RoundRestSeconds: 60,
+				Members: []CircuitMember{
+					{Position: 1, WorkoutID: 1, Workout: squat},
+					{Position: 2, WorkoutID: 2, Workout: press, WorkSeconds: &twenty},
+				},
+			},
+			[]CircuitPhase{
+				{PhaseWork, 1, 1, 1, "Squat", 0, 40},
+				{PhaseRest, 1, 2, 2, "Press", 40, 15},
+				{PhaseWork, 1, 2, 2, "Press", 55, 20},
+				{PhaseRoundRest, 2, 1, 1, "Squat", 75, 60},
+				{PhaseWork, 2, 1, 1, "Squat", 135, 40},
+				{PhaseRest, 2, 2, 2, "Press", 175, 15},
+				{PhaseWork, 2, 2, 2, "Press", 190, 20},
+			},
+		},
+		{
+			"superset without rest between members",
+			Circuit{
+				Rounds:           2,
+				WorkSeconds:      30,
+				RoundRestSeconds: 90,
+				Members: []CircuitMember{
+					{Position: 1, WorkoutID: 1, Workout: squat},
+					{Position: 2, WorkoutID: 2, Workout: press},
+				},
+			},
+			[]CircuitPhase{
+				{PhaseWork, 1, 1, 1, "Squat", 0, 30},
+				{PhaseWork, 1, 2, 2, "Press", 30, 30},
+				{PhaseRoundRest, 2, 1, 1, "Squat", 60, 90},
+				{PhaseWork, 2, 1, 1, "Squat", 150, 30},
+				{PhaseWork, 2, 2, 2, "Press", 180, 30},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.circuit.Timeline()
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v want %+v", got, tt.want)
+			}
+		})
+	}
+}
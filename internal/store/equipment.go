@@ -3,12 +3,11 @@ package store
 import (
 	"context"
 	"database/sql"
-
-	"github.com/lib/pq"
+	"fmt"
 )
 
 type EquipmentStore struct {
-	db *sql.DB
+	db *storeDB
 }
 
 type Equipment struct {
@@ -23,46 +22,66 @@ func (s *EquipmentStore) Create(ctx context.Context, equipment *Equipment) error
 	defer cancel()
 
 	if err := s.db.QueryRowContext(ctx, query, &equipment.Name).Scan(&equipment.ID); err != nil {
-		// check unique constraints validation
-		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23505" {
-			return ErrDuplicate
-		}
-		return err
+		return translateError(err)
 	}
 
 	return nil
 }
 
-func (s *EquipmentStore) GetAll(ctx context.Context) ([]Equipment, error) {
-	query := `
+func (s *EquipmentStore) GetAll(ctx context.Context, page PaginatedQuery) ([]Equipment, PageMeta, error) {
+	ks, err := page.keyset(EquipmentSorts, "e.id", 0)
+	if err != nil {
+		return nil, PageMeta{}, err
+	}
+
+	query := fmt.Sprintf(`
     SELECT
-    id, name
-    FROM equipment
-    ;`
+    %s, e.id, e.name
+    FROM equipment e
+    WHERE %s
+    ORDER BY %s
+    LIMIT %d
+    ;`, ks.sortKey, ks.where, ks.orderBy, ks.limit)
+
+	countQuery := `SELECT COUNT(*) FROM equipment;`
 
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
 	defer cancel()
 
-	rows, err := s.db.QueryContext(ctx, query)
+	var total int
+	if err := s.db.QueryRowContext(ctx, countQuery).Scan(&total); err != nil {
+		return nil, PageMeta{}, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, ks.args...)
 	if err != nil {
-		return nil, err
+		return nil, PageMeta{}, err
 	}
 	defer rows.Close()
 
-	var equipment []Equipment
+	equipment := []Equipment{}
+	var positions []cursor
 	for rows.Next() {
 		var e Equipment
+		var c cursor
 		err := rows.Scan(
+			&c.Key,
 			&e.ID,
 			&e.Name,
 		)
 		if err != nil {
-			return nil, err
+			return nil, PageMeta{}, err
 		}
+		c.ID = e.ID
 		equipment = append(equipment, e)
+		positions = append(positions, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, PageMeta{}, err
 	}
 
-	return equipment, nil
+	equipment, meta := paginate(page, ks, equipment, positions, total)
+	return equipment, meta, nil
 }
 
 func (s *EquipmentStore) GetByID(ctx context.Context, id int64) (*Equipment, error) {
@@ -120,7 +139,7 @@ func (s *EquipmentStore) Update(ctx context.Context, equipment *Equipment) error
 
 	res, err := s.db.ExecContext(ctx, query, equipment.Name, equipment.ID)
 	if err != nil {
-		return err
+		return translateError(err)
 	}
 
 	rowsAffected, err := res.RowsAffected()
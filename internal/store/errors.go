@@ -0,0 +1,105 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrForeignKey    = errors.New("referenced resource does not exist")
+	ErrCheck         = errors.New("value is out of the allowed range")
+	ErrNotNull       = errors.New("value is required")
+	ErrInvalidValue  = errors.New("value is not valid for its type")
+	ErrValueTooLong  = errors.New("value is too long")
+	ErrSerialization = errors.New("concurrent update, retry the request")
+)
+
+// constraintErrors maps the SQLSTATE codes of rejected writes to the store
+// errors they are reported as.
+var constraintErrors = map[pq.ErrorCode]error{
+	"23505": ErrDuplicate,
+	"23503": ErrForeignKey,
+	"23514": ErrCheck,
+	"23502": ErrNotNull,
+	"22P02": ErrInvalidValue,
+	"22001": ErrValueTooLong,
+}
+
+// keyDetailPattern picks the columns out of a unique or foreign key detail
+// such as `Key (bodypart_id)=(99) is not present in table "body_part".`
+var keyDetailPattern = regexp.MustCompile(`^Key \(([^)]+)\)=`)
+
+// ConstraintError is a write the database rejected. Err is one of the store
+// errors above and Column the column at fault, when Postgres reports it.
+type ConstraintError struct {
+	Err        error
+	Table      string
+	Constraint string
+	Column     string
+}
+
+func (e *ConstraintError) Error() string {
+	if e.Column != "" {
+		return fmt.Sprintf("%s: %s.%s", e.Err, e.Table, e.Column)
+	}
+	return fmt.Sprintf("%s: %s", e.Err, e.Constraint)
+}
+
+func (e *ConstraintError) Unwrap() error {
+	return e.Err
+}
+
+// translateError turns Postgres errors into store errors, anything else is
+// returned unchanged.
+func translateError(err error) error {
+	var pgErr *pq.Error
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case "40001", "40P01":
+		return ErrSerialization
+	}
+
+	kind, ok := constraintErrors[pgErr.Code]
+	if !ok {
+		return err
+	}
+
+	return &ConstraintError{
+		Err:        kind,
+		Table:      pgErr.Table,
+		Constraint: pgErr.Constraint,
+		Column:     constraintColumn(pgErr),
+	}
+}
+
+// constraintColumn finds the column of a violation from, in order, the
+// error itself, the key in its detail or a default check constraint name.
+func constraintColumn(pgErr *pq.Error) string {
+	if pgErr.Column != "" {
+		return pgErr.Column
+	}
+
+	if m := keyDetailPattern.FindStringSubmatch(pgErr.Detail); m != nil {
+		columns := strings.Split(m[1], ", ")
+		// composite keys are scoped by their leading columns, the last one is
+		// the value the caller supplied
+		return columns[len(columns)-1]
+	}
+
+	// Postgres names unnamed check constraints <table>_<column>_check
+	if pgErr.Code == "23514" && pgErr.Table != "" {
+		column, ok := strings.CutPrefix(pgErr.Constraint, pgErr.Table+"_")
+		if column, found := strings.CutSuffix(column, "_check"); ok && found {
+			return column
+		}
+	}
+
+	return ""
+}
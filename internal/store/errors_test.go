@@ -0,0 +1,76 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestTranslateError(t *testing.T) {
+	other := errors.New("connection refused")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantErr    error
+		wantColumn string
+	}{
+		{"unique key",
+			&pq.Error{Code: "23505", Table: "body_part", Constraint: "body_part_name_key", Detail: "Key (name)=(Chest) already exists."},
+			ErrDuplicate, "name"},
+		{"foreign key",
+			&pq.Error{Code: "23503", Table: "target", Constraint: "target_bodypart_id_fkey", Detail: `Key (bodypart_id)=(99) is not present in table "body_part".`},
+			ErrForeignKey, "bodypart_id"},
+		{"composite key names the last column",
+			&pq.Error{Code: "23503", Table: "workout_target", Constraint: "workout_target_target_id_fkey", Detail: `Key (workout_id, target_id)=(1, 99) is not present in table "target".`},
+			ErrForeignKey, "target_id"},
+		{"default check constraint name",
+			&pq.Error{Code: "23514", Table: "routine_item", Constraint: "routine_item_reps_max_check"},
+			ErrCheck, "reps_max"},
+		{"named check constraint",
+			&pq.Error{Code: "23514", Table: "routine_item", Constraint: "reps_in_order"},
+			ErrCheck, ""},
+		{"not null",
+			&pq.Error{Code: "23502", Table: "workout", Column: "difficulty"},
+			ErrNotNull, "difficulty"},
+		{"enum input", &pq.Error{Code: "22P02", Message: `invalid input value for enum workout_difficulty: "expert"`}, ErrInvalidValue, ""},
+		{"string truncation", &pq.Error{Code: "22001", Message: "value too long for type character varying(40)"}, ErrValueTooLong, ""},
+		{"wrapped", fmt.Errorf("insert: %w", &pq.Error{Code: "23505", Detail: "Key (email)=(a@b.c) already exists."}), ErrDuplicate, "email"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := translateError(tt.err)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v want %v", err, tt.wantErr)
+			}
+
+			var constraintErr *ConstraintError
+			if !errors.As(err, &constraintErr) {
+				t.Fatalf("got %T want *ConstraintError", err)
+			}
+
+			if constraintErr.Column != tt.wantColumn {
+				t.Errorf("got column %q want %q", constraintErr.Column, tt.wantColumn)
+			}
+		})
+	}
+
+	t.Run("serialization failures are retryable conflicts", func(t *testing.T) {
+		for _, code := range []pq.ErrorCode{"40001", "40P01"} {
+			if err := translateError(&pq.Error{Code: code}); err != ErrSerialization {
+				t.Errorf("%s: got %v want %v", code, err, ErrSerialization)
+			}
+		}
+	})
+
+	t.Run("other errors are returned unchanged", func(t *testing.T) {
+		for _, err := range []error{nil, other, &pq.Error{Code: "42P01"}} {
+			if got := translateError(err); got != err {
+				t.Errorf("got %v want %v", got, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type WorkoutFilter struct {
+	BodyPartID  int64  `json:"bodypart_id" validate:"gte=0"`
+	EquipmentID int64  `json:"equipment_id" validate:"gte=0"`
+	Difficulty  string `json:"difficulty" validate:"omitempty,oneof=beginner intermediate advanced"`
+	TargetID    int64  `json:"target_id" validate:"required_with=TargetType,gte=0"`
+	TargetType  string `json:"target_type" validate:"omitempty,oneof=primary secondary"`
+}
+
+type TargetFilter struct {
+	BodyPartID int64 `json:"bodypart_id" validate:"gte=0"`
+}
+
+// SessionFilter narrows session history to sessions started in [From, To).
+// Nil bounds leave that side open.
+type SessionFilter struct {
+	From *time.Time `json:"from"`
+	To   *time.Time `json:"to"`
+}
+
+func (f SessionFilter) args() []any {
+	return []any{f.From, f.To}
+}
+
+// CandidateFilter selects the workouts the session generator may use. An
+// empty EquipmentIDs allows any equipment.
+type CandidateFilter struct {
+	BodyPartIDs   []int64
+	EquipmentIDs  []int64
+	MaxDifficulty string
+}
+
+// candidateFilterClause narrows workouts using the CandidateFilter arguments
+// $1 to $3, in field order. Difficulties compare in the order of the enum.
+const candidateFilterClause = `
+    w.bodypart_id = ANY($1)
+    AND (cardinality($2::bigint[]) = 0 OR w.equipment_id = ANY($2))
+    AND w.difficulty <= $3::workout_difficulty`
+
+func (f CandidateFilter) args() []any {
+	return []any{pq.Array(f.BodyPartIDs), pq.Array(f.EquipmentIDs), f.MaxDifficulty}
+}
+
+type SearchQuery struct {
+	Query string `json:"q" validate:"required,max=100"`
+	Limit int    `json:"limit" validate:"gte=1,lte=50"`
+}
+
+type AutocompleteQuery struct {
+	Query string   `json:"q" validate:"required,max=50"`
+	Types []string `json:"types" validate:"required,dive,oneof=workout target equipment bodypart"`
+	Limit int      `json:"limit" validate:"gte=1,lte=25"`
+}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/metrics"
+)
+
+// queryer runs the read queries shared between stores. It is satisfied by
+// both *sql.DB and storeDB.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// storeDB is the connection pool as seen by one store. It records the
+// duration and failures of every statement under the store's name,
+// transactions are recorded as a whole by withTx.
+type storeDB struct {
+	*sql.DB
+	store string
+}
+
+func newStoreDB(db *sql.DB, store string) *storeDB {
+	return &storeDB{DB: db, store: store}
+}
+
+// observe records a statement that started at start. sql.ErrNoRows is a
+// result rather than a failure.
+func (db *storeDB) observe(operation string, start time.Time, err error) {
+	metrics.StoreQueryDuration.WithLabelValues(db.store, operation).Observe(time.Since(start).Seconds())
+	if err != nil && err != sql.ErrNoRows {
+		metrics.StoreQueryErrors.WithLabelValues(db.store, operation).Inc()
+	}
+}
+
+func (db *storeDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	res, err := db.DB.ExecContext(ctx, query, args...)
+	db.observe("exec", start, err)
+	return res, err
+}
+
+func (db *storeDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := db.DB.QueryContext(ctx, query, args...)
+	db.observe("query", start, err)
+	return rows, err
+}
+
+// QueryRowContext only sees errors raised by the query itself, failures of
+// the caller's Scan are not counted.
+func (db *storeDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := db.DB.QueryRowContext(ctx, query, args...)
+	db.observe("query_row", start, row.Err())
+	return row
+}
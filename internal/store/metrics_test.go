@@ -0,0 +1,28 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestStoreDBObserve(t *testing.T) {
+	db := newStoreDB(nil, "observe_test")
+	errs := metrics.StoreQueryErrors.WithLabelValues("observe_test", "exec")
+
+	db.observe("exec", time.Now(), nil)
+	db.observe("exec", time.Now(), sql.ErrNoRows)
+	db.observe("exec", time.Now(), errors.New("connection refused"))
+
+	if got := testutil.ToFloat64(errs); got != 1 {
+		t.Errorf("got %v errors want 1", got)
+	}
+
+	if got := testutil.CollectAndCount(metrics.StoreQueryDuration, "mfit_store_query_duration_seconds"); got == 0 {
+		t.Error("expected the durations to be recorded")
+	}
+}
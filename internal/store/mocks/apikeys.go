@@ -0,0 +1,77 @@
+package mocks
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/auth"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+// Plaintext keys known to the mock store.
+const (
+	MockAPIKey         = "mfit_00000001_writer"
+	MockReadOnlyAPIKey = "mfit_00000002_reader"
+	MockRevokedAPIKey  = "mfit_00000003_revoked"
+	MockExpiredAPIKey  = "mfit_00000004_expired"
+)
+
+type MockAPIKeyStore struct {
+}
+
+func mockAPIKeys() []store.APIKey {
+	past := time.Now().Add(-time.Hour)
+
+	return []store.APIKey{
+		{ID: 1, Name: "content pipeline", Prefix: "mfit_00000001", Hash: auth.HashAPIKey(MockAPIKey), Scopes: []string{store.ScopeCatalogRead, store.ScopeCatalogWrite}},
+		{ID: 2, Name: "gym kiosk", Prefix: "mfit_00000002", Hash: auth.HashAPIKey(MockReadOnlyAPIKey), Scopes: []string{store.ScopeCatalogRead}},
+		{ID: 3, Name: "old kiosk", Prefix: "mfit_00000003", Hash: auth.HashAPIKey(MockRevokedAPIKey), Scopes: []string{store.ScopeCatalogRead}, RevokedAt: &past},
+		{ID: 4, Name: "trial", Prefix: "mfit_00000004", Hash: auth.HashAPIKey(MockExpiredAPIKey), Scopes: []string{store.ScopeCatalogRead}, ExpiresAt: &past},
+	}
+}
+
+func (m *MockAPIKeyStore) Create(_ context.Context, key *store.APIKey) error {
+	key.ID = 5
+	return nil
+}
+
+func (m *MockAPIKeyStore) GetByID(_ context.Context, id int64) (*store.APIKey, error) {
+	for _, key := range mockAPIKeys() {
+		if key.ID == id {
+			return &key, nil
+		}
+	}
+	return nil, store.ErrNotFound
+}
+
+func (m *MockAPIKeyStore) GetByHash(_ context.Context, hash []byte) (*store.APIKey, error) {
+	for _, key := range mockAPIKeys() {
+		if bytes.Equal(key.Hash, hash) {
+			return &key, nil
+		}
+	}
+	return nil, store.ErrNotFound
+}
+
+func (m *MockAPIKeyStore) GetAll(context.Context) ([]store.APIKey, error) {
+	return mockAPIKeys(), nil
+}
+
+func (m *MockAPIKeyStore) UpdateExpiry(_ context.Context, key *store.APIKey) error {
+	if key.RevokedAt != nil {
+		return store.ErrAPIKeyRevoked
+	}
+	return nil
+}
+
+func (m *MockAPIKeyStore) Revoke(_ context.Context, id int64) error {
+	if id == 3 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+func (m *MockAPIKeyStore) Touch(context.Context, int64) error {
+	return nil
+}
@@ -0,0 +1,14 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+type MockAutocompleteStore struct {
+}
+
+func (m *MockAutocompleteStore) Suggest(context.Context, store.AutocompleteQuery) ([]store.Suggestion, error) {
+	return nil, nil
+}
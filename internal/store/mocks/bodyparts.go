@@ -17,8 +17,8 @@ func (m *MockBodyPartStore) GetByID(context.Context, int64) (*store.BodyPart, er
 	return nil, nil
 }
 
-func (m *MockBodyPartStore) GetAll(context.Context) ([]store.BodyPart, error) {
-	return nil, nil
+func (m *MockBodyPartStore) GetAll(context.Context, store.PaginatedQuery) ([]store.BodyPart, store.PageMeta, error) {
+	return nil, store.PageMeta{}, nil
 }
 
 func (m *MockBodyPartStore) Update(context.Context, *store.BodyPart) error {
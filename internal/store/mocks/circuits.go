@@ -0,0 +1,58 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+// MockCircuitID is a two member circuit created by the mock admin, every
+// other circuit is missing.
+const MockCircuitID = 1
+
+type MockCircuitStore struct {
+}
+
+func (m *MockCircuitStore) Create(_ context.Context, circuit *store.Circuit) error {
+	circuit.ID = 2
+	for i := range circuit.Members {
+		circuit.Members[i].ID = int64(i + 1)
+	}
+	return nil
+}
+
+func (m *MockCircuitStore) GetByID(ctx context.Context, id int64) (*store.Circuit, error) {
+	if id != MockCircuitID {
+		return nil, store.ErrNotFound
+	}
+
+	workouts, _ := new(MockWorkoutStore).GetByIDs(ctx, []int64{1, 2})
+
+	return &store.Circuit{
+		ID:               id,
+		UserID:           MockAdminID,
+		Name:             "Test Circuit",
+		Kind:             "circuit",
+		Rounds:           2,
+		WorkSeconds:      40,
+		RestSeconds:      20,
+		RoundRestSeconds: 60,
+		Members: []store.CircuitMember{
+			{ID: 1, Position: 1, WorkoutID: 1, Workout: &workouts[0]},
+			{ID: 2, Position: 2, WorkoutID: 2, Workout: &workouts[1]},
+		},
+	}, nil
+}
+
+func (m *MockCircuitStore) GetAll(ctx context.Context) ([]store.Circuit, error) {
+	circuit, _ := m.GetByID(ctx, MockCircuitID)
+	return []store.Circuit{*circuit}, nil
+}
+
+func (m *MockCircuitStore) Update(context.Context, *store.Circuit) error {
+	return nil
+}
+
+func (m *MockCircuitStore) Delete(context.Context, int64) error {
+	return nil
+}
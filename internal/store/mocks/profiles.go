@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+// MockProfileStore knows a profile for the mock admin only.
+type MockProfileStore struct {
+}
+
+func (m *MockProfileStore) GetByUserID(_ context.Context, userID int64) (*store.Profile, error) {
+	if userID != MockAdminID {
+		return nil, store.ErrNotFound
+	}
+
+	height, weight, birthYear := 180.0, 80.0, 1990
+	level := "intermediate"
+
+	return &store.Profile{
+		UserID:          MockAdminID,
+		HeightCm:        &height,
+		WeightKg:        &weight,
+		BirthYear:       &birthYear,
+		ExperienceLevel: &level,
+		WeightUnit:      "kg",
+		LengthUnit:      "cm",
+	}, nil
+}
+
+func (m *MockProfileStore) Upsert(context.Context, *store.Profile) error {
+	return nil
+}
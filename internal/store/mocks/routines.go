@@ -0,0 +1,69 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+// MockAdminRoutineID belongs to the mock admin and MockMemberRoutineID to the
+// mock member, every other routine is missing.
+const (
+	MockAdminRoutineID  = 1
+	MockMemberRoutineID = 2
+)
+
+type MockRoutineStore struct {
+}
+
+func (m *MockRoutineStore) Create(_ context.Context, routine *store.Routine) error {
+	routine.ID = 3
+	for i := range routine.Items {
+		routine.Items[i].ID = int64(i + 1)
+	}
+	return nil
+}
+
+func (m *MockRoutineStore) GetByID(ctx context.Context, id int64) (*store.Routine, error) {
+	var userID int64
+	switch id {
+	case MockAdminRoutineID:
+		userID = MockAdminID
+	case MockMemberRoutineID:
+		userID = MockMemberID
+	default:
+		return nil, store.ErrNotFound
+	}
+
+	workout, _ := new(MockWorkoutStore).GetByID(ctx, 1)
+	routine := &store.Routine{
+		ID:     id,
+		UserID: userID,
+		Name:   "Test Routine",
+		Items: []store.RoutineItem{
+			{ID: 1, Position: 1, WorkoutID: 1, Sets: 3, RestSeconds: 60, Workout: workout},
+		},
+	}
+	routine.EstimatedDurationSeconds = routine.EstimateDuration()
+
+	return routine, nil
+}
+
+func (m *MockRoutineStore) GetAllByUser(ctx context.Context, userID int64) ([]store.Routine, error) {
+	routines := []store.Routine{}
+	for _, id := range []int64{MockAdminRoutineID, MockMemberRoutineID} {
+		routine, _ := m.GetByID(ctx, id)
+		if routine.UserID == userID {
+			routines = append(routines, *routine)
+		}
+	}
+	return routines, nil
+}
+
+func (m *MockRoutineStore) Update(context.Context, *store.Routine) error {
+	return nil
+}
+
+func (m *MockRoutineStore) Delete(context.Context, int64) error {
+	return nil
+}
@@ -0,0 +1,90 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+// Sessions of the mock member, one still open and one finished, and one of
+// the mock admin. MockFinishingSessionID reads as open but is finished by the
+// time a set is logged. Every other session is missing.
+const (
+	MockOpenSessionID      = 1
+	MockFinishedSessionID  = 2
+	MockAdminSessionID     = 3
+	MockFinishingSessionID = 5
+)
+
+type MockSessionStore struct {
+}
+
+func (m *MockSessionStore) Create(_ context.Context, session *store.Session) error {
+	session.ID = 4
+	if session.StartedAt == "" {
+		session.StartedAt = "2026-01-05T07:00:00Z"
+	}
+	return nil
+}
+
+func (m *MockSessionStore) GetByID(_ context.Context, id int64) (*store.Session, error) {
+	reps, load := 5, 100.0
+
+	session := &store.Session{
+		ID:        id,
+		UserID:    MockMemberID,
+		StartedAt: "2026-01-05T07:00:00Z",
+		Totals:    store.SessionTotals{Sets: 1, Workouts: 1, Reps: reps, VolumeKg: float64(reps) * load},
+		Sets: []store.SessionSet{
+			{ID: 1, SessionID: id, WorkoutID: 1, SetNumber: 1, Reps: &reps, LoadKg: &load},
+		},
+	}
+
+	switch id {
+	case MockOpenSessionID, MockFinishingSessionID:
+	case MockFinishedSessionID:
+		finished := "2026-01-05T08:00:00Z"
+		session.FinishedAt = &finished
+	case MockAdminSessionID:
+		session.UserID = MockAdminID
+	default:
+		return nil, store.ErrNotFound
+	}
+
+	return session, nil
+}
+
+func (m *MockSessionStore) GetAllByUser(ctx context.Context, userID int64, _ store.SessionFilter, _ store.PaginatedQuery) ([]store.Session, store.PageMeta, error) {
+	sessions := []store.Session{}
+	for _, id := range []int64{MockOpenSessionID, MockFinishedSessionID, MockAdminSessionID} {
+		session, _ := m.GetByID(ctx, id)
+		if session.UserID == userID {
+			session.Sets = nil
+			sessions = append(sessions, *session)
+		}
+	}
+	return sessions, store.PageMeta{Limit: 20, Sort: "id", Total: len(sessions)}, nil
+}
+
+// AddSet rejects workouts from MockMissingWorkoutID up like the foreign key
+// would.
+func (m *MockSessionStore) AddSet(_ context.Context, set *store.SessionSet) error {
+	if set.SessionID == MockFinishingSessionID {
+		return store.ErrSessionFinished
+	}
+	if set.WorkoutID >= MockMissingWorkoutID {
+		return store.ErrUnknownWorkout
+	}
+	set.ID = 2
+	set.SetNumber = 1
+	return nil
+}
+
+func (m *MockSessionStore) Finish(_ context.Context, session *store.Session) error {
+	if session.FinishedAt != nil {
+		return store.ErrSessionFinished
+	}
+	finished := "2026-01-05T08:00:00Z"
+	session.FinishedAt = &finished
+	return nil
+}
@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+// MockStrengthStandardStore has standards for male lifters on workout 1 only.
+type MockStrengthStandardStore struct {
+}
+
+func (m *MockStrengthStandardStore) Get(_ context.Context, workoutID int64, sex string) (*store.StrengthStandard, error) {
+	if workoutID != 1 || sex != "male" {
+		return nil, store.ErrNotFound
+	}
+
+	return &store.StrengthStandard{
+		WorkoutID:    workoutID,
+		Sex:          sex,
+		Beginner:     0.5,
+		Novice:       0.75,
+		Intermediate: 1.25,
+		Advanced:     1.75,
+		Elite:        2.25,
+	}, nil
+}
+
+func (m *MockStrengthStandardStore) Upsert(context.Context, *store.StrengthStandard) error {
+	return nil
+}
@@ -0,0 +1,113 @@
+package mocks
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/auth"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+const (
+	// MockTOTPSecret is the confirmed secret of the mock admin and the pending
+	// one of the mock member.
+	MockTOTPSecret   = "JBSWY3DPEHPK3PXP"
+	MockRecoveryCode = "abcde-12345"
+)
+
+// MockTOTPStore remembers used steps, recovery codes and counted attempts so
+// replay protection and lockout can be exercised.
+type MockTOTPStore struct {
+	mu                 sync.Mutex
+	lastUsedStep       int64
+	recoveryCodeIsUsed bool
+	failedAttempts     int
+	lockedUntil        *time.Time
+}
+
+func (m *MockTOTPStore) Get(_ context.Context, userID int64) (*store.TOTP, error) {
+	switch userID {
+	case MockAdminID:
+		confirmedAt := time.Now().Add(-time.Hour)
+		return &store.TOTP{UserID: MockAdminID, Secret: MockTOTPSecret, ConfirmedAt: &confirmedAt}, nil
+	case MockMemberID:
+		return &store.TOTP{UserID: MockMemberID, Secret: MockTOTPSecret}, nil
+	default:
+		return nil, store.ErrNotFound
+	}
+}
+
+func (m *MockTOTPStore) Enroll(_ context.Context, t *store.TOTP) error {
+	if t.UserID == MockAdminID {
+		return store.ErrTOTPEnrolled
+	}
+	return nil
+}
+
+func (m *MockTOTPStore) Confirm(context.Context, int64, int64, [][]byte) error {
+	return nil
+}
+
+func (m *MockTOTPStore) UseStep(_ context.Context, _ int64, step int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if step <= m.lastUsedStep {
+		return store.ErrTOTPReplay
+	}
+	m.lastUsedStep = step
+	return nil
+}
+
+func (m *MockTOTPStore) UseRecoveryCode(_ context.Context, userID int64, hash []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if userID != MockAdminID || m.recoveryCodeIsUsed || !bytes.Equal(hash, auth.HashRecoveryCode(MockRecoveryCode)) {
+		return store.ErrNotFound
+	}
+	m.recoveryCodeIsUsed = true
+	return nil
+}
+
+func (m *MockTOTPStore) ClaimAttempt(_ context.Context, userID int64, lockAfter int, lockout time.Duration) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if userID != MockAdminID {
+		return time.Time{}, store.ErrNotFound
+	}
+
+	if m.lockedUntil != nil {
+		if time.Now().Before(*m.lockedUntil) {
+			return *m.lockedUntil, store.ErrTOTPLocked
+		}
+		m.lockedUntil = nil
+		m.failedAttempts = 0
+	}
+
+	m.failedAttempts++
+	if m.failedAttempts >= lockAfter {
+		lockedUntil := time.Now().Add(lockout)
+		m.lockedUntil = &lockedUntil
+	}
+	return time.Time{}, nil
+}
+
+func (m *MockTOTPStore) ResetFailures(context.Context, int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.failedAttempts = 0
+	m.lockedUntil = nil
+	return nil
+}
+
+func (m *MockTOTPStore) Delete(_ context.Context, userID int64) error {
+	if userID != MockAdminID {
+		return store.ErrNotFound
+	}
+	return nil
+}
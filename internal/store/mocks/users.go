@@ -0,0 +1,119 @@
+package mocks
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/auth"
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+const (
+	MockUserEmail    = "test@example.com"
+	MockUserPassword = "password123"
+
+	// MockInactiveEmail belongs to a user who has not activated their account
+	// yet, they share the mock password.
+	MockInactiveEmail = "inactive@example.com"
+
+	// MockAdminID and MockMemberID identify the users the mock store knows.
+	MockAdminID  = 1
+	MockMemberID = 2
+
+	// Plaintext one-time tokens the mock store accepts.
+	MockActivationToken = "mock-activation-token"
+	MockResetToken      = "mock-reset-token"
+)
+
+type MockUserStore struct {
+}
+
+func (m *MockUserStore) Create(_ context.Context, user *store.User) error {
+	if user.Email == MockUserEmail {
+		return store.ErrDuplicateEmail
+	}
+	user.ID = 3
+	user.Role = store.RoleMember
+	return nil
+}
+
+func (m *MockUserStore) CreateAndInvite(ctx context.Context, user *store.User, _ []byte, _ time.Duration) error {
+	return m.Create(ctx, user)
+}
+
+func (m *MockUserStore) GetByID(_ context.Context, id int64) (*store.User, error) {
+	switch id {
+	case MockAdminID:
+		return mockUser()
+	case MockMemberID:
+		return &store.User{ID: MockMemberID, Username: "member", Email: "member@example.com", Role: store.RoleMember, IsActive: true}, nil
+	default:
+		return nil, store.ErrNotFound
+	}
+}
+
+func (m *MockUserStore) GetByEmail(_ context.Context, email string) (*store.User, error) {
+	switch email {
+	case MockUserEmail:
+		return mockUser()
+	case MockInactiveEmail:
+		user, err := mockUser()
+		if err != nil {
+			return nil, err
+		}
+		user.ID, user.Username, user.Email, user.Role, user.IsActive = 4, "inactive", MockInactiveEmail, store.RoleMember, false
+		return user, nil
+	default:
+		return nil, store.ErrNotFound
+	}
+}
+
+func (m *MockUserStore) UpdateRole(_ context.Context, user *store.User) error {
+	return nil
+}
+
+func (m *MockUserStore) Delete(context.Context, int64) error {
+	return nil
+}
+
+func (m *MockUserStore) CreateToken(context.Context, int64, string, []byte, time.Duration) error {
+	return nil
+}
+
+func (m *MockUserStore) Activate(_ context.Context, hash []byte) (*store.User, error) {
+	if !bytes.Equal(hash, auth.HashOneTimeToken(MockActivationToken)) {
+		return nil, store.ErrNotFound
+	}
+	return &store.User{ID: 4, Username: "inactive", Email: MockInactiveEmail, Role: store.RoleMember, IsActive: true}, nil
+}
+
+func (m *MockUserStore) ResetPassword(_ context.Context, hash []byte, user *store.User) error {
+	if !bytes.Equal(hash, auth.HashOneTimeToken(MockResetToken)) {
+		return store.ErrNotFound
+	}
+	user.ID, user.Username, user.Email, user.Role, user.IsActive = MockAdminID, "test", MockUserEmail, store.RoleAdmin, true
+	return nil
+}
+
+// mockUserOnce hashes the mock password once, bcrypt is deliberately slow.
+var mockUserOnce = sync.OnceValues(func() (store.User, error) {
+	user := store.User{
+		ID:       MockAdminID,
+		Username: "test",
+		Email:    MockUserEmail,
+		Role:     store.RoleAdmin,
+		IsActive: true,
+	}
+	err := user.Password.Set(MockUserPassword)
+	return user, err
+})
+
+func mockUser() (*store.User, error) {
+	user, err := mockUserOnce()
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
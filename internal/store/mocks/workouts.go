@@ -0,0 +1,132 @@
+package mocks
+
+import (
+	"context"
+	"slices"
+
+	"github.com/JerryLegend254/mfit_api/internal/store"
+)
+
+// MockMissingWorkoutID and above are treated as nonexistent workouts.
+const MockMissingWorkoutID = 100
+
+// MockUntargetedWorkoutID has no target links, like workouts imported before
+// targets were required.
+const MockUntargetedWorkoutID = 99
+
+type MockWorkoutStore struct {
+}
+
+func (m *MockWorkoutStore) CreateAndLinkTargets(context.Context, *store.Workout, int64, []int64) error {
+	return nil
+}
+
+func (m *MockWorkoutStore) GetByID(_ context.Context, id int64) (*store.PresentableWorkout, error) {
+	if id == MockUntargetedWorkoutID {
+		return &store.PresentableWorkout{
+			Workout: store.Workout{ID: id, Name: "Untargeted Workout", BodyPartID: 1, EquipmentID: 1, Difficulty: "beginner"},
+		}, nil
+	}
+
+	return &store.PresentableWorkout{
+		Workout: store.Workout{
+			ID:          id,
+			Name:        "Test Workout",
+			BodyPartID:  1,
+			EquipmentID: 1,
+			Difficulty:  "beginner",
+			// five minutes keeps duration estimates easy to check by hand
+			DurationMinutes: 5,
+		},
+		PrimaryTargetID:    1,
+		SecondaryTargetIDs: []int64{2},
+	}, nil
+}
+
+// GetByIDs knows every workout below MockMissingWorkoutID.
+func (m *MockWorkoutStore) GetByIDs(ctx context.Context, ids []int64) ([]store.PresentableWorkout, error) {
+	workouts := []store.PresentableWorkout{}
+	for _, id := range ids {
+		if id >= MockMissingWorkoutID {
+			continue
+		}
+		w, _ := m.GetByID(ctx, id)
+		workouts = append(workouts, *w)
+	}
+	return workouts, nil
+}
+
+// GetTargets links odd workouts to pushing targets and even ones to pulling
+// targets, each with a primary and a secondary target.
+func (m *MockWorkoutStore) GetTargets(_ context.Context, ids []int64) (map[int64][]store.WorkoutTarget, error) {
+	push := []store.WorkoutTarget{
+		{ID: 1, Name: "pectorals", Type: "primary", BodyPartID: 1, BodyPart: "chest"},
+		{ID: 2, Name: "triceps", Type: "secondary", BodyPartID: 2, BodyPart: "upper arms"},
+	}
+	pull := []store.WorkoutTarget{
+		{ID: 3, Name: "lats", Type: "primary", BodyPartID: 3, BodyPart: "back"},
+		{ID: 4, Name: "biceps", Type: "secondary", BodyPartID: 2, BodyPart: "upper arms"},
+	}
+
+	targets := make(map[int64][]store.WorkoutTarget, len(ids))
+	for _, id := range ids {
+		switch {
+		case id >= MockMissingWorkoutID:
+		case id%2 == 1:
+			targets[id] = push
+		default:
+			targets[id] = pull
+		}
+	}
+	return targets, nil
+}
+
+// GetCandidates has four workouts per body part, alternating between
+// equipment 1 and 2, with rising difficulty and two primary targets per body
+// part. Every workout takes ten minutes.
+func (m *MockWorkoutStore) GetCandidates(_ context.Context, filter store.CandidateFilter) ([]store.PresentableWorkout, error) {
+	difficulties := []string{"beginner", "beginner", "intermediate", "advanced"}
+	rank := map[string]int{"beginner": 0, "intermediate": 1, "advanced": 2}
+
+	workouts := []store.PresentableWorkout{}
+	for _, bodyPartID := range filter.BodyPartIDs {
+		for i := range 4 {
+			w := store.PresentableWorkout{
+				Workout: store.Workout{
+					ID:              bodyPartID*10 + int64(i),
+					Name:            "Test Workout",
+					BodyPartID:      bodyPartID,
+					EquipmentID:     int64(i%2 + 1),
+					Difficulty:      difficulties[i],
+					DurationMinutes: 10,
+				},
+				PrimaryTargetID: bodyPartID*10 + int64(i/2),
+			}
+
+			if rank[w.Difficulty] > rank[filter.MaxDifficulty] {
+				continue
+			}
+			if len(filter.EquipmentIDs) > 0 && !slices.Contains(filter.EquipmentIDs, w.EquipmentID) {
+				continue
+			}
+			workouts = append(workouts, w)
+		}
+	}
+	return workouts, nil
+}
+
+func (m *MockWorkoutStore) GetAll(context.Context, store.WorkoutFilter, store.PaginatedQuery) ([]store.PresentableWorkout, store.PageMeta, error) {
+	return nil, store.PageMeta{}, nil
+}
+
+func (m *MockWorkoutStore) UpdateAndLinkTargets(context.Context, *store.Workout, int64, []int64) error {
+	return nil
+}
+
+func (m *MockWorkoutStore) Delete(context.Context, int64) error {
+	return nil
+}
+
+func (m *MockWorkoutStore) Search(context.Context, store.SearchQuery) ([]store.WorkoutSearchResult, error) {
+	return nil, nil
+}
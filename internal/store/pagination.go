@@ -0,0 +1,186 @@
+package store
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInvalidSort   = errors.New("invalid sort parameter")
+	ErrInvalidCursor = errors.New("invalid cursor parameter")
+)
+
+// Defaults for a PaginatedQuery that leaves the limit or sort out.
+const (
+	DefaultPageLimit = 20
+	DefaultSort      = "id"
+)
+
+// SortColumns maps the sort names accepted by a collection endpoint to the
+// SQL expressions they order by.
+type SortColumns map[string]string
+
+var (
+	BodyPartSorts  = SortColumns{"id": "b.id", "name": "b.name"}
+	TargetSorts    = SortColumns{"id": "t.id", "name": "t.name", "body_part": "b.name"}
+	EquipmentSorts = SortColumns{"id": "e.id", "name": "e.name"}
+	WorkoutSorts   = SortColumns{
+		"id":               "w.id",
+		"name":             "w.name",
+		"difficulty":       "w.difficulty",
+		"duration_minutes": "COALESCE(w.duration_minutes, 0)",
+		"calories_burned":  "COALESCE(w.calories_burned, 0)",
+	}
+	SessionSorts = SortColumns{"id": "s.id", "started_at": "s.started_at"}
+)
+
+type PaginatedQuery struct {
+	Limit  int    `json:"limit" validate:"gte=1,lte=100"`
+	Cursor string `json:"cursor"`
+	Sort   string `json:"sort"`
+}
+
+type PageMeta struct {
+	Limit      int    `json:"limit"`
+	Sort       string `json:"sort"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	Total      int    `json:"total"`
+}
+
+// cursor is the decoded form of the opaque cursor handed to clients. It pins
+// the sort it was issued for and the position of the row it points at.
+type cursor struct {
+	Sort string `json:"s"`
+	Key  string `json:"k"`
+	ID   int64  `json:"i"`
+	Prev bool   `json:"p,omitempty"`
+}
+
+func (c cursor) encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (*cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// Check reports whether the sort is one the collection supports and the
+// cursor was issued for that sort.
+func (q PaginatedQuery) Check(sorts SortColumns) error {
+	_, err := q.keyset(sorts, "", 0)
+	return err
+}
+
+type keyset struct {
+	// sortKey is the sort expression cast to text, selected with every row
+	// so that cursors can be built from the page boundaries.
+	sortKey  string
+	where    string
+	orderBy  string
+	limit    int
+	args     []any
+	backward bool
+}
+
+// keyset builds the clauses for a keyset page. Placeholders are numbered
+// after argOffset so the clauses can be appended to filtered queries.
+func (q PaginatedQuery) keyset(sorts SortColumns, idColumn string, argOffset int) (*keyset, error) {
+	sort := cmp.Or(q.Sort, DefaultSort)
+	desc := strings.HasPrefix(sort, "-")
+
+	column, ok := sorts[strings.TrimPrefix(sort, "-")]
+	if !ok {
+		return nil, ErrInvalidSort
+	}
+
+	ks := &keyset{
+		sortKey: fmt.Sprintf("(%s)::text", column),
+		where:   "TRUE",
+		limit:   cmp.Or(q.Limit, DefaultPageLimit) + 1,
+	}
+
+	if q.Cursor != "" {
+		c, err := decodeCursor(q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if c.Sort != sort {
+			return nil, ErrInvalidCursor
+		}
+
+		ks.backward = c.Prev
+		op := ">"
+		if desc != c.Prev {
+			op = "<"
+		}
+		ks.where = fmt.Sprintf("(%s, %s) %s ($%d, $%d)", column, idColumn, op, argOffset+1, argOffset+2)
+		ks.args = []any{c.Key, c.ID}
+	}
+
+	dir := "ASC"
+	if desc != ks.backward {
+		dir = "DESC"
+	}
+	ks.orderBy = fmt.Sprintf("%s %s, %s %s", column, dir, idColumn, dir)
+
+	return ks, nil
+}
+
+// paginate trims the lookahead row fetched by a keyset query, restores the
+// requested order for backward pages and builds the cursors around the page.
+func paginate[T any](q PaginatedQuery, ks *keyset, items []T, positions []cursor, total int) ([]T, PageMeta) {
+	meta := PageMeta{
+		Limit: ks.limit - 1,
+		Sort:  cmp.Or(q.Sort, DefaultSort),
+		Total: total,
+	}
+
+	hasMore := len(items) > meta.Limit
+	if hasMore {
+		items = items[:meta.Limit]
+		positions = positions[:meta.Limit]
+	}
+
+	hasNext, hasPrev := hasMore, q.Cursor != ""
+	if ks.backward {
+		slices.Reverse(items)
+		slices.Reverse(positions)
+		hasNext, hasPrev = true, hasMore
+	}
+
+	if len(positions) == 0 {
+		return items, meta
+	}
+
+	if hasNext {
+		next := positions[len(positions)-1]
+		next.Sort = meta.Sort
+		meta.NextCursor = next.encode()
+	}
+
+	if hasPrev {
+		prev := positions[0]
+		prev.Sort = meta.Sort
+		prev.Prev = true
+		meta.PrevCursor = prev.encode()
+	}
+
+	return items, meta
+}
@@ -0,0 +1,143 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPaginatedQueryCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   PaginatedQuery
+		wantErr error
+	}{
+		{"defaults", PaginatedQuery{}, nil},
+		{"descending sort", PaginatedQuery{Limit: 5, Sort: "-name"}, nil},
+		{"unknown sort", PaginatedQuery{Sort: "image_url"}, ErrInvalidSort},
+		{"malformed cursor", PaginatedQuery{Cursor: "!!!"}, ErrInvalidCursor},
+		{"cursor for another sort", PaginatedQuery{Sort: "name", Cursor: cursor{Sort: "id", Key: "1", ID: 1}.encode()}, ErrInvalidCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.query.Check(BodyPartSorts); err != tt.wantErr {
+				t.Errorf("got error %v want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	tests := []struct {
+		name  string
+		query PaginatedQuery
+		where string
+		order string
+		args  []any
+	}{
+		{
+			"first page",
+			PaginatedQuery{Limit: 10, Sort: "name"},
+			"TRUE",
+			"b.name ASC, b.id ASC",
+			nil,
+		},
+		{
+			"next page descending",
+			PaginatedQuery{Limit: 10, Sort: "-name", Cursor: cursor{Sort: "-name", Key: "Chest", ID: 4}.encode()},
+			"(b.name, b.id) < ($2, $3)",
+			"b.name DESC, b.id DESC",
+			[]any{"Chest", int64(4)},
+		},
+		{
+			"previous page ascending",
+			PaginatedQuery{Limit: 10, Sort: "name", Cursor: cursor{Sort: "name", Key: "Chest", ID: 4, Prev: true}.encode()},
+			"(b.name, b.id) < ($2, $3)",
+			"b.name DESC, b.id DESC",
+			[]any{"Chest", int64(4)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, err := tt.query.keyset(BodyPartSorts, "b.id", 1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ks.where != tt.where {
+				t.Errorf("got where %q want %q", ks.where, tt.where)
+			}
+			if ks.orderBy != tt.order {
+				t.Errorf("got order %q want %q", ks.orderBy, tt.order)
+			}
+			if !reflect.DeepEqual(ks.args, tt.args) {
+				t.Errorf("got args %v want %v", ks.args, tt.args)
+			}
+			if ks.limit != 11 {
+				t.Errorf("got limit %d want %d", ks.limit, 11)
+			}
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	positions := func(ids ...int64) []cursor {
+		var c []cursor
+		for _, id := range ids {
+			c = append(c, cursor{Key: "k", ID: id})
+		}
+		return c
+	}
+
+	t.Run("first page with more rows", func(t *testing.T) {
+		q := PaginatedQuery{Limit: 2, Sort: "id"}
+		ks, _ := q.keyset(BodyPartSorts, "b.id", 0)
+
+		items, meta := paginate(q, ks, []int64{1, 2, 3}, positions(1, 2, 3), 3)
+
+		if !reflect.DeepEqual(items, []int64{1, 2}) {
+			t.Errorf("got %v want %v", items, []int64{1, 2})
+		}
+		if meta.PrevCursor != "" {
+			t.Errorf("got prev cursor %q on the first page", meta.PrevCursor)
+		}
+		next, err := decodeCursor(meta.NextCursor)
+		if err != nil || next.ID != 2 || next.Prev {
+			t.Errorf("got next cursor %+v", next)
+		}
+		if meta.Total != 3 || meta.Limit != 2 {
+			t.Errorf("got meta %+v", meta)
+		}
+	})
+
+	t.Run("backward page is returned in requested order", func(t *testing.T) {
+		q := PaginatedQuery{Limit: 2, Sort: "id", Cursor: cursor{Sort: "id", Key: "4", ID: 4, Prev: true}.encode()}
+		ks, _ := q.keyset(BodyPartSorts, "b.id", 0)
+
+		items, meta := paginate(q, ks, []int64{3, 2, 1}, positions(3, 2, 1), 5)
+
+		if !reflect.DeepEqual(items, []int64{2, 3}) {
+			t.Errorf("got %v want %v", items, []int64{2, 3})
+		}
+		prev, err := decodeCursor(meta.PrevCursor)
+		if err != nil || prev.ID != 2 || !prev.Prev {
+			t.Errorf("got prev cursor %+v", prev)
+		}
+		next, err := decodeCursor(meta.NextCursor)
+		if err != nil || next.ID != 3 || next.Prev {
+			t.Errorf("got next cursor %+v", next)
+		}
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		q := PaginatedQuery{Limit: 2, Sort: "id", Cursor: cursor{Sort: "id", Key: "2", ID: 2}.encode()}
+		ks, _ := q.keyset(BodyPartSorts, "b.id", 0)
+
+		_, meta := paginate(q, ks, []int64{3}, positions(3), 3)
+
+		if meta.NextCursor != "" {
+			t.Errorf("got next cursor %q on the last page", meta.NextCursor)
+		}
+		if meta.PrevCursor == "" {
+			t.Error("expected a prev cursor")
+		}
+	})
+}
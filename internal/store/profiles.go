@@ -0,0 +1,97 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+)
+
+type ProfileStore struct {
+	db *storeDB
+}
+
+// Profile holds a user's body metrics in metric units along with the units
+// they prefer to see them in.
+type Profile struct {
+	UserID          int64    `json:"user_id"`
+	HeightCm        *float64 `json:"height_cm"`
+	WeightKg        *float64 `json:"weight_kg"`
+	BirthYear       *int     `json:"birth_year"`
+	Sex             *string  `json:"sex"`
+	ExperienceLevel *string  `json:"experience_level"`
+	Goal            *string  `json:"goal"`
+	WeightUnit      string   `json:"weight_unit"`
+	LengthUnit      string   `json:"length_unit"`
+	UpdatedAt       string   `json:"updated_at"`
+}
+
+func (s *ProfileStore) GetByUserID(ctx context.Context, userID int64) (*Profile, error) {
+	query := `
+    SELECT user_id, height_cm, weight_kg, birth_year, sex, experience_level, goal, weight_unit, length_unit, updated_at
+    FROM user_profile
+    WHERE user_id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var profile Profile
+	err := s.db.QueryRowContext(ctx, query, userID).Scan(
+		&profile.UserID,
+		&profile.HeightCm,
+		&profile.WeightKg,
+		&profile.BirthYear,
+		&profile.Sex,
+		&profile.ExperienceLevel,
+		&profile.Goal,
+		&profile.WeightUnit,
+		&profile.LengthUnit,
+		&profile.UpdatedAt,
+	)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &profile, nil
+}
+
+// Upsert creates or replaces the user's profile.
+func (s *ProfileStore) Upsert(ctx context.Context, profile *Profile) error {
+	query := `
+    INSERT INTO user_profile (user_id, height_cm, weight_kg, birth_year, sex, experience_level, goal, weight_unit, length_unit)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    ON CONFLICT (user_id) DO UPDATE
+    SET height_cm = EXCLUDED.height_cm,
+        weight_kg = EXCLUDED.weight_kg,
+        birth_year = EXCLUDED.birth_year,
+        sex = EXCLUDED.sex,
+        experience_level = EXCLUDED.experience_level,
+        goal = EXCLUDED.goal,
+        weight_unit = EXCLUDED.weight_unit,
+        length_unit = EXCLUDED.length_unit,
+        updated_at = NOW()
+    RETURNING updated_at
+    ;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	err := s.db.QueryRowContext(
+		ctx,
+		query,
+		profile.UserID,
+		profile.HeightCm,
+		profile.WeightKg,
+		profile.BirthYear,
+		profile.Sex,
+		profile.ExperienceLevel,
+		profile.Goal,
+		profile.WeightUnit,
+		profile.LengthUnit,
+	).Scan(&profile.UpdatedAt)
+
+	return translateError(err)
+}
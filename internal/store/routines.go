@@ -0,0 +1,333 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+var ErrUnknownWorkout = errors.New("a referenced workout does not exist")
+
+type RoutineStore struct {
+	db *storeDB
+}
+
+type Routine struct {
+	ID                       int64         `json:"id"`
+	UserID                   int64         `json:"user_id"`
+	Name                     string        `json:"name"`
+	Description              string        `json:"description"`
+	Items                    []RoutineItem `json:"items"`
+	EstimatedDurationSeconds int           `json:"estimated_duration_seconds"`
+	CreatedAt                string        `json:"created_at"`
+	UpdatedAt                string        `json:"updated_at"`
+}
+
+type RoutineItem struct {
+	ID              int64               `json:"id"`
+	Position        int                 `json:"position"`
+	WorkoutID       int64               `json:"workout_id"`
+	Sets            int                 `json:"sets"`
+	RepsMin         *int                `json:"reps_min"`
+	RepsMax         *int                `json:"reps_max"`
+	TargetLoadKg    *float64            `json:"target_load_kg"`
+	DurationSeconds *int                `json:"duration_seconds"`
+	RestSeconds     int                 `json:"rest_seconds"`
+	Workout         *PresentableWorkout `json:"workout"`
+}
+
+// EstimateDuration sums the work and rest time of the routine in seconds.
+// Timed items work for sets × duration_seconds, others for the catalog's
+// duration_minutes of the exercise. Every set is followed by the item's rest
+// except the last set of the routine.
+func (r *Routine) EstimateDuration() int {
+	total := 0
+
+	for _, item := range r.Items {
+		switch {
+		case item.DurationSeconds != nil:
+			total += item.Sets * *item.DurationSeconds
+		case item.Workout != nil:
+			total += int(item.Workout.DurationMinutes) * 60
+		}
+
+		total += item.Sets * item.RestSeconds
+	}
+
+	if n := len(r.Items); n > 0 {
+		total -= r.Items[n-1].RestSeconds
+	}
+
+	return total
+}
+
+func (s *RoutineStore) Create(ctx context.Context, routine *Routine) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		query := `
+        INSERT INTO routine (user_id, name, description)
+        VALUES ($1, $2, $3)
+        RETURNING id, created_at, updated_at
+        ;`
+
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+		defer cancel()
+
+		err := tx.QueryRowContext(ctx, query, routine.UserID, routine.Name, routine.Description).Scan(
+			&routine.ID,
+			&routine.CreatedAt,
+			&routine.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		return s.insertItems(ctx, tx, routine)
+	})
+}
+
+// Update saves the routine's name and description and replaces its items.
+func (s *RoutineStore) Update(ctx context.Context, routine *Routine) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		query := `
+        UPDATE routine SET name = $1, description = $2, updated_at = NOW()
+        WHERE id = $3
+        RETURNING updated_at
+        ;`
+
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+		defer cancel()
+
+		err := tx.QueryRowContext(ctx, query, routine.Name, routine.Description, routine.ID).Scan(&routine.UpdatedAt)
+		if err != nil {
+			switch err {
+			case sql.ErrNoRows:
+				return ErrNotFound
+			default:
+				return err
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM routine_item WHERE routine_id = $1;`, routine.ID); err != nil {
+			return err
+		}
+
+		return s.insertItems(ctx, tx, routine)
+	})
+}
+
+func (s *RoutineStore) insertItems(ctx context.Context, tx *sql.Tx, routine *Routine) error {
+	query := `
+    INSERT INTO routine_item (routine_id, workout_id, position, sets, reps_min, reps_max, target_load_kg, duration_seconds, rest_seconds)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    RETURNING id
+    ;`
+
+	for i := range routine.Items {
+		item := &routine.Items[i]
+		item.Position = i + 1
+
+		err := tx.QueryRowContext(
+			ctx,
+			query,
+			routine.ID,
+			item.WorkoutID,
+			item.Position,
+			item.Sets,
+			item.RepsMin,
+			item.RepsMax,
+			item.TargetLoadKg,
+			item.DurationSeconds,
+			item.RestSeconds,
+		).Scan(&item.ID)
+		if err != nil {
+			// foreign key violation on workout_id
+			err = translateError(err)
+			if errors.Is(err, ErrForeignKey) {
+				return ErrUnknownWorkout
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *RoutineStore) GetByID(ctx context.Context, id int64) (*Routine, error) {
+	query := `
+    SELECT id, user_id, name, description, created_at, updated_at
+    FROM routine
+    WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var routine Routine
+	err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&routine.ID,
+		&routine.UserID,
+		&routine.Name,
+		&routine.Description,
+		&routine.CreatedAt,
+		&routine.UpdatedAt,
+	)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	routines := []Routine{routine}
+	if err := s.loadItems(ctx, routines); err != nil {
+		return nil, err
+	}
+
+	return &routines[0], nil
+}
+
+func (s *RoutineStore) GetAllByUser(ctx context.Context, userID int64) ([]Routine, error) {
+	query := `
+    SELECT id, user_id, name, description, created_at, updated_at
+    FROM routine
+    WHERE user_id = $1
+    ORDER BY id`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	routines := []Routine{}
+	for rows.Next() {
+		var routine Routine
+		err := rows.Scan(
+			&routine.ID,
+			&routine.UserID,
+			&routine.Name,
+			&routine.Description,
+			&routine.CreatedAt,
+			&routine.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		routines = append(routines, routine)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadItems(ctx, routines); err != nil {
+		return nil, err
+	}
+
+	return routines, nil
+}
+
+// loadItems attaches the items of every routine, each expanded with its
+// workout, and fills in the duration estimates. Items and workouts are
+// fetched with one query each regardless of the number of routines.
+func (s *RoutineStore) loadItems(ctx context.Context, routines []Routine) error {
+	if len(routines) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(routines))
+	for i := range routines {
+		ids[i] = routines[i].ID
+	}
+
+	query := `
+    SELECT id, routine_id, position, workout_id, sets, reps_min, reps_max, target_load_kg, duration_seconds, rest_seconds
+    FROM routine_item
+    WHERE routine_id = ANY($1)
+    ORDER BY routine_id, position`
+
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	items := make(map[int64][]RoutineItem)
+	var workoutIDs []int64
+	for rows.Next() {
+		var item RoutineItem
+		var routineID int64
+		err := rows.Scan(
+			&item.ID,
+			&routineID,
+			&item.Position,
+			&item.WorkoutID,
+			&item.Sets,
+			&item.RepsMin,
+			&item.RepsMax,
+			&item.TargetLoadKg,
+			&item.DurationSeconds,
+			&item.RestSeconds,
+		)
+		if err != nil {
+			return err
+		}
+		items[routineID] = append(items[routineID], item)
+		workoutIDs = append(workoutIDs, item.WorkoutID)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	workouts, err := getWorkoutsByIDs(s.db, ctx, workoutIDs)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[int64]*PresentableWorkout, len(workouts))
+	for i := range workouts {
+		byID[workouts[i].ID] = &workouts[i]
+	}
+
+	for i := range routines {
+		routines[i].Items = items[routines[i].ID]
+		if routines[i].Items == nil {
+			routines[i].Items = []RoutineItem{}
+		}
+
+		for j := range routines[i].Items {
+			routines[i].Items[j].Workout = byID[routines[i].Items[j].WorkoutID]
+		}
+
+		routines[i].EstimatedDurationSeconds = routines[i].EstimateDuration()
+	}
+
+	return nil
+}
+
+func (s *RoutineStore) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM routine WHERE id = $1;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package store
+
+import "testing"
+
+func TestRoutineEstimateDuration(t *testing.T) {
+	thirty := 30
+
+	tests := []struct {
+		name  string
+		items []RoutineItem
+		want  int
+	}{
+		{"empty", nil, 0},
+		{
+			"catalog duration with rests between sets",
+			[]RoutineItem{{Sets: 3, RestSeconds: 90, Workout: &PresentableWorkout{Workout: Workout{DurationMinutes: 5}}}},
+			300 + 2*90,
+		},
+		{
+			"timed item followed by a catalog item",
+			[]RoutineItem{
+				{Sets: 3, DurationSeconds: &thirty, RestSeconds: 15},
+				{Sets: 2, RestSeconds: 60, Workout: &PresentableWorkout{Workout: Workout{DurationMinutes: 4}}},
+			},
+			3*30 + 3*15 + 240 + 60,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Routine{Items: tt.items}
+
+			if got := r.EstimateDuration(); got != tt.want {
+				t.Errorf("got %d want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,317 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var ErrSessionFinished = errors.New("session is already finished")
+
+type SessionStore struct {
+	db *storeDB
+}
+
+type Session struct {
+	ID         int64         `json:"id"`
+	UserID     int64         `json:"user_id"`
+	RoutineID  *int64        `json:"routine_id"`
+	Notes      string        `json:"notes"`
+	StartedAt  string        `json:"started_at"`
+	FinishedAt *string       `json:"finished_at"`
+	Totals     SessionTotals `json:"totals"`
+	Sets       []SessionSet  `json:"sets,omitempty"`
+}
+
+// SessionTotals sums a session's sets. VolumeKg only counts sets that have
+// both reps and a load.
+type SessionTotals struct {
+	Sets            int     `json:"sets"`
+	Workouts        int     `json:"workouts"`
+	Reps            int     `json:"reps"`
+	VolumeKg        float64 `json:"volume_kg"`
+	DurationSeconds int     `json:"duration_seconds"`
+	DistanceMeters  float64 `json:"distance_meters"`
+}
+
+type SessionSet struct {
+	ID              int64    `json:"id"`
+	SessionID       int64    `json:"session_id"`
+	WorkoutID       int64    `json:"workout_id"`
+	SetNumber       int      `json:"set_number"`
+	Reps            *int     `json:"reps"`
+	LoadKg          *float64 `json:"load_kg"`
+	RPE             *float64 `json:"rpe"`
+	DurationSeconds *int     `json:"duration_seconds"`
+	DistanceMeters  *float64 `json:"distance_meters"`
+	CreatedAt       string   `json:"created_at"`
+}
+
+// sessionColumns and sessionFrom select sessions together with the totals of
+// their sets.
+const (
+	sessionColumns = `
+    s.id, s.user_id, s.routine_id, s.notes, s.started_at, s.finished_at,
+    COALESCE(t.sets, 0), COALESCE(t.workouts, 0), COALESCE(t.reps, 0), COALESCE(t.volume_kg, 0),
+    COALESCE(t.duration_seconds, 0), COALESCE(t.distance_meters, 0)`
+
+	sessionFrom = `
+    FROM session s
+    LEFT JOIN LATERAL (
+        SELECT
+        COUNT(*) AS sets,
+        COUNT(DISTINCT ss.workout_id) AS workouts,
+        SUM(ss.reps) AS reps,
+        SUM(ss.reps * ss.load_kg) AS volume_kg,
+        SUM(ss.duration_seconds) AS duration_seconds,
+        SUM(ss.distance_meters) AS distance_meters
+        FROM session_set ss
+        WHERE ss.session_id = s.id
+    ) t ON TRUE`
+)
+
+func scanSession(row interface{ Scan(...any) error }, dest ...any) (*Session, error) {
+	var s Session
+	err := row.Scan(append(dest,
+		&s.ID,
+		&s.UserID,
+		&s.RoutineID,
+		&s.Notes,
+		&s.StartedAt,
+		&s.FinishedAt,
+		&s.Totals.Sets,
+		&s.Totals.Workouts,
+		&s.Totals.Reps,
+		&s.Totals.VolumeKg,
+		&s.Totals.DurationSeconds,
+		&s.Totals.DistanceMeters,
+	)...)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Create starts a session, at the given StartedAt or now when it is empty.
+func (s *SessionStore) Create(ctx context.Context, session *Session) error {
+	query := `
+    INSERT INTO session (user_id, routine_id, notes, started_at)
+    VALUES ($1, $2, $3, COALESCE(NULLIF($4, '')::timestamptz, NOW()))
+    RETURNING id, started_at
+    ;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	err := s.db.QueryRowContext(ctx, query, session.UserID, session.RoutineID, session.Notes, session.StartedAt).Scan(
+		&session.ID,
+		&session.StartedAt,
+	)
+
+	return translateError(err)
+}
+
+func (s *SessionStore) GetByID(ctx context.Context, id int64) (*Session, error) {
+	query := fmt.Sprintf(`SELECT %s %s WHERE s.id = $1;`, sessionColumns, sessionFrom)
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	session, err := scanSession(s.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	setsQuery := `
+    SELECT id, session_id, workout_id, set_number, reps, load_kg, rpe, duration_seconds, distance_meters, created_at
+    FROM session_set
+    WHERE session_id = $1
+    ORDER BY id`
+
+	rows, err := s.db.QueryContext(ctx, setsQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	session.Sets = []SessionSet{}
+	for rows.Next() {
+		var set SessionSet
+		err := rows.Scan(
+			&set.ID,
+			&set.SessionID,
+			&set.WorkoutID,
+			&set.SetNumber,
+			&set.Reps,
+			&set.LoadKg,
+			&set.RPE,
+			&set.DurationSeconds,
+			&set.DistanceMeters,
+			&set.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		session.Sets = append(session.Sets, set)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// GetAllByUser lists a user's sessions with their totals, without sets.
+func (s *SessionStore) GetAllByUser(ctx context.Context, userID int64, filter SessionFilter, page PaginatedQuery) ([]Session, PageMeta, error) {
+	ks, err := page.keyset(SessionSorts, "s.id", 3)
+	if err != nil {
+		return nil, PageMeta{}, err
+	}
+
+	filterClause := `
+    s.user_id = $1
+    AND ($2::timestamptz IS NULL OR s.started_at >= $2)
+    AND ($3::timestamptz IS NULL OR s.started_at < $3)`
+
+	query := fmt.Sprintf(`
+    SELECT %s, %s
+    %s
+    WHERE %s
+    AND %s
+    ORDER BY %s
+    LIMIT %d
+    ;`, ks.sortKey, sessionColumns, sessionFrom, filterClause, ks.where, ks.orderBy, ks.limit)
+
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM session s WHERE %s;`, filterClause)
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	filterArgs := append([]any{userID}, filter.args()...)
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, countQuery, filterArgs...).Scan(&total); err != nil {
+		return nil, PageMeta{}, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, append(filterArgs, ks.args...)...)
+	if err != nil {
+		return nil, PageMeta{}, err
+	}
+	defer rows.Close()
+
+	sessions := []Session{}
+	var positions []cursor
+	for rows.Next() {
+		var c cursor
+		session, err := scanSession(rows, &c.Key)
+		if err != nil {
+			return nil, PageMeta{}, err
+		}
+
+		c.ID = session.ID
+		sessions = append(sessions, *session)
+		positions = append(positions, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, PageMeta{}, err
+	}
+
+	sessions, meta := paginate(page, ks, sessions, positions, total)
+
+	return sessions, meta, nil
+}
+
+// AddSet logs a set, numbering it after the earlier sets of the same workout
+// in the session. The session row is locked while the set is numbered so
+// concurrent sets get consecutive numbers, and a session finished in the
+// meantime rejects the set with ErrSessionFinished.
+func (s *SessionStore) AddSet(ctx context.Context, set *SessionSet) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		lock := `SELECT id FROM session WHERE id = $1 FOR UPDATE;`
+
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+		defer cancel()
+
+		var id int64
+		if err := tx.QueryRowContext(ctx, lock, set.SessionID).Scan(&id); err != nil {
+			switch err {
+			case sql.ErrNoRows:
+				return ErrNotFound
+			default:
+				return err
+			}
+		}
+
+		query := `
+        INSERT INTO session_set (session_id, workout_id, set_number, reps, load_kg, rpe, duration_seconds, distance_meters)
+        SELECT s.id, $2, COALESCE((
+            SELECT MAX(set_number) FROM session_set
+            WHERE session_id = $1 AND workout_id = $2
+        ), 0) + 1, $3, $4, $5, $6, $7
+        FROM session s
+        WHERE s.id = $1 AND s.finished_at IS NULL
+        RETURNING id, set_number, created_at
+        ;`
+
+		err := tx.QueryRowContext(
+			ctx,
+			query,
+			set.SessionID,
+			set.WorkoutID,
+			set.Reps,
+			set.LoadKg,
+			set.RPE,
+			set.DurationSeconds,
+			set.DistanceMeters,
+		).Scan(
+			&set.ID,
+			&set.SetNumber,
+			&set.CreatedAt,
+		)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrSessionFinished
+			}
+			// foreign key violation on workout_id
+			err = translateError(err)
+			if errors.Is(err, ErrForeignKey) {
+				return ErrUnknownWorkout
+			}
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Finish stamps the session's finish time, a session can only be finished
+// once.
+func (s *SessionStore) Finish(ctx context.Context, session *Session) error {
+	query := `
+    UPDATE session SET finished_at = NOW()
+    WHERE id = $1 AND finished_at IS NULL
+    RETURNING finished_at
+    ;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	err := s.db.QueryRowContext(ctx, query, session.ID).Scan(&session.FinishedAt)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return ErrSessionFinished
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
@@ -5,63 +5,153 @@ import (
 	"database/sql"
 	"errors"
 	"time"
+
+	"github.com/JerryLegend254/mfit_api/internal/logger"
 )
 
 var (
 	QueryTimeoutDuration = time.Second * 5
 	ErrNotFound          = errors.New("resource not found")
 	ErrDuplicate         = errors.New("duplicate entry already exists")
-	ErrDuplicateName     = errors.New("duplicate resource with name already exists")
 )
 
 type Storage struct {
 	BodyParts interface {
 		Create(context.Context, *BodyPart) error
 		GetByID(context.Context, int64) (*BodyPart, error)
-		GetAll(context.Context) ([]BodyPart, error)
+		GetAll(context.Context, PaginatedQuery) ([]BodyPart, PageMeta, error)
 		Update(context.Context, *BodyPart) error
 		Delete(context.Context, int64) error
 	}
 	Targets interface {
 		Create(context.Context, *Target) error
 		GetByID(context.Context, int64) (*PresentableTarget, error)
-		GetAll(context.Context) ([]PresentableTarget, error)
+		GetAll(context.Context, TargetFilter, PaginatedQuery) ([]PresentableTarget, PageMeta, error)
 		Update(context.Context, *PresentableTarget) error
 		Delete(context.Context, int64) error
 	}
 	Equipment interface {
 		Create(context.Context, *Equipment) error
 		GetByID(context.Context, int64) (*Equipment, error)
-		GetAll(context.Context) ([]Equipment, error)
+		GetAll(context.Context, PaginatedQuery) ([]Equipment, PageMeta, error)
 		Update(context.Context, *Equipment) error
 		Delete(context.Context, int64) error
 	}
 	Workouts interface {
 		CreateAndLinkTargets(context.Context, *Workout, int64, []int64) error
 		GetByID(context.Context, int64) (*PresentableWorkout, error)
-		GetAll(context.Context) ([]PresentableWorkout, error)
+		GetByIDs(context.Context, []int64) ([]PresentableWorkout, error)
+		GetTargets(context.Context, []int64) (map[int64][]WorkoutTarget, error)
+		GetCandidates(context.Context, CandidateFilter) ([]PresentableWorkout, error)
+		GetAll(context.Context, WorkoutFilter, PaginatedQuery) ([]PresentableWorkout, PageMeta, error)
+		UpdateAndLinkTargets(context.Context, *Workout, int64, []int64) error
+		Delete(context.Context, int64) error
+		Search(context.Context, SearchQuery) ([]WorkoutSearchResult, error)
+	}
+	Autocomplete interface {
+		Suggest(context.Context, AutocompleteQuery) ([]Suggestion, error)
+	}
+	Users interface {
+		Create(context.Context, *User) error
+		GetByID(context.Context, int64) (*User, error)
+		GetByEmail(context.Context, string) (*User, error)
+		UpdateRole(context.Context, *User) error
+		CreateAndInvite(context.Context, *User, []byte, time.Duration) error
+		Delete(context.Context, int64) error
+		CreateToken(context.Context, int64, string, []byte, time.Duration) error
+		Activate(context.Context, []byte) (*User, error)
+		ResetPassword(context.Context, []byte, *User) error
+	}
+	APIKeys interface {
+		Create(context.Context, *APIKey) error
+		GetByID(context.Context, int64) (*APIKey, error)
+		GetByHash(context.Context, []byte) (*APIKey, error)
+		GetAll(context.Context) ([]APIKey, error)
+		UpdateExpiry(context.Context, *APIKey) error
+		Revoke(context.Context, int64) error
+		Touch(context.Context, int64) error
+	}
+	TOTP interface {
+		Get(context.Context, int64) (*TOTP, error)
+		Enroll(context.Context, *TOTP) error
+		Confirm(context.Context, int64, int64, [][]byte) error
+		UseStep(context.Context, int64, int64) error
+		UseRecoveryCode(context.Context, int64, []byte) error
+		ClaimAttempt(context.Context, int64, int, time.Duration) (time.Time, error)
+		ResetFailures(context.Context, int64) error
+		Delete(context.Context, int64) error
+	}
+	Profiles interface {
+		GetByUserID(context.Context, int64) (*Profile, error)
+		Upsert(context.Context, *Profile) error
+	}
+	Routines interface {
+		Create(context.Context, *Routine) error
+		GetByID(context.Context, int64) (*Routine, error)
+		GetAllByUser(context.Context, int64) ([]Routine, error)
+		Update(context.Context, *Routine) error
+		Delete(context.Context, int64) error
+	}
+	Circuits interface {
+		Create(context.Context, *Circuit) error
+		GetByID(context.Context, int64) (*Circuit, error)
+		GetAll(context.Context) ([]Circuit, error)
+		Update(context.Context, *Circuit) error
+		Delete(context.Context, int64) error
+	}
+	Sessions interface {
+		Create(context.Context, *Session) error
+		GetByID(context.Context, int64) (*Session, error)
+		GetAllByUser(context.Context, int64, SessionFilter, PaginatedQuery) ([]Session, PageMeta, error)
+		AddSet(context.Context, *SessionSet) error
+		Finish(context.Context, *Session) error
+	}
+	StrengthStandards interface {
+		Get(context.Context, int64, string) (*StrengthStandard, error)
+		Upsert(context.Context, *StrengthStandard) error
 	}
 }
 
 func NewStorage(db *sql.DB) Storage {
 	return Storage{
-		BodyParts: &BodyPartStore{db},
-		Targets:   &TargetStore{db},
-		Equipment: &EquipmentStore{db},
-		Workouts:  &WorkoutStore{db},
+		BodyParts:         &BodyPartStore{newStoreDB(db, "bodyparts")},
+		Targets:           &TargetStore{newStoreDB(db, "targets")},
+		Equipment:         &EquipmentStore{newStoreDB(db, "equipment")},
+		Workouts:          &WorkoutStore{newStoreDB(db, "workouts")},
+		Autocomplete:      &AutocompleteStore{newStoreDB(db, "autocomplete")},
+		Users:             &UserStore{newStoreDB(db, "users")},
+		APIKeys:           &APIKeyStore{newStoreDB(db, "apikeys")},
+		TOTP:              &TOTPStore{newStoreDB(db, "totp")},
+		Profiles:          &ProfileStore{newStoreDB(db, "profiles")},
+		Routines:          &RoutineStore{newStoreDB(db, "routines")},
+		Circuits:          &CircuitStore{newStoreDB(db, "circuits")},
+		Sessions:          &SessionStore{newStoreDB(db, "sessions")},
+		StrengthStandards: &StrengthStandardStore{newStoreDB(db, "strength_standards")},
 	}
 }
 
-func withTx(ctx context.Context, db *sql.DB, fn func(*sql.Tx) error) error {
+// withTx runs fn in a transaction, recorded as a single "tx" operation of
+// the store.
+func withTx(ctx context.Context, db *storeDB, fn func(*sql.Tx) error) (err error) {
+	defer func(start time.Time) {
+		db.observe("tx", start, err)
+	}(time.Now())
+
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 
 	if err := fn(tx); err != nil {
-		_ = tx.Rollback()
-		return err
+		if rbErr := tx.Rollback(); rbErr != nil {
+			// the request logger carries the request ID, tying the failure
+			// to the access log entry of the same request
+			if l, ok := logger.FromContext(ctx); ok {
+				l.Warnw("rollback failed", "error", rbErr.Error(), "cause", err.Error())
+			}
+		}
+		return translateError(err)
 	}
 
-	return tx.Commit()
+	return translateError(tx.Commit())
 }
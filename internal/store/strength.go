@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+)
+
+type StrengthStandardStore struct {
+	db *storeDB
+}
+
+// StrengthStandard holds the 1RM to bodyweight ratios for each strength
+// level of a workout.
+type StrengthStandard struct {
+	WorkoutID    int64   `json:"workout_id"`
+	Sex          string  `json:"sex"`
+	Beginner     float64 `json:"beginner"`
+	Novice       float64 `json:"novice"`
+	Intermediate float64 `json:"intermediate"`
+	Advanced     float64 `json:"advanced"`
+	Elite        float64 `json:"elite"`
+	UpdatedAt    string  `json:"updated_at"`
+}
+
+func (s *StrengthStandardStore) Get(ctx context.Context, workoutID int64, sex string) (*StrengthStandard, error) {
+	query := `
+    SELECT workout_id, sex, beginner, novice, intermediate, advanced, elite, updated_at
+    FROM strength_standard
+    WHERE workout_id = $1 AND sex = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var standard StrengthStandard
+	err := s.db.QueryRowContext(ctx, query, workoutID, sex).Scan(
+		&standard.WorkoutID,
+		&standard.Sex,
+		&standard.Beginner,
+		&standard.Novice,
+		&standard.Intermediate,
+		&standard.Advanced,
+		&standard.Elite,
+		&standard.UpdatedAt,
+	)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &standard, nil
+}
+
+// Upsert creates or replaces the standard for the workout and sex.
+func (s *StrengthStandardStore) Upsert(ctx context.Context, standard *StrengthStandard) error {
+	query := `
+    INSERT INTO strength_standard (workout_id, sex, beginner, novice, intermediate, advanced, elite)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+    ON CONFLICT (workout_id, sex) DO UPDATE
+    SET beginner = EXCLUDED.beginner,
+        novice = EXCLUDED.novice,
+        intermediate = EXCLUDED.intermediate,
+        advanced = EXCLUDED.advanced,
+        elite = EXCLUDED.elite,
+        updated_at = NOW()
+    RETURNING updated_at
+    ;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	err := s.db.QueryRowContext(
+		ctx,
+		query,
+		standard.WorkoutID,
+		standard.Sex,
+		standard.Beginner,
+		standard.Novice,
+		standard.Intermediate,
+		standard.Advanced,
+		standard.Elite,
+	).Scan(&standard.UpdatedAt)
+
+	return translateError(err)
+}
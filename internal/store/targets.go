@@ -3,12 +3,13 @@ package store
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/lib/pq"
 )
 
 type TargetStore struct {
-	db *sql.DB
+	db *storeDB
 }
 
 type Target struct {
@@ -29,49 +30,73 @@ func (s *TargetStore) Create(ctx context.Context, target *Target) error {
 	defer cancel()
 
 	if err := s.db.QueryRowContext(ctx, query, &target.Name, &target.BodyPartID).Scan(&target.ID); err != nil {
-		// check unique constraints validation
-		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23505" {
-			return ErrDuplicate
-		}
-		return err
+		return translateError(err)
 	}
 
 	return nil
 }
 
-func (s *TargetStore) GetAll(ctx context.Context) ([]PresentableTarget, error) {
-	query := `
+func (s *TargetStore) GetAll(ctx context.Context, filter TargetFilter, page PaginatedQuery) ([]PresentableTarget, PageMeta, error) {
+	ks, err := page.keyset(TargetSorts, "t.id", 1)
+	if err != nil {
+		return nil, PageMeta{}, err
+	}
+
+	filterClause := `(t.bodypart_id = $1 OR $1 = 0)`
+
+	query := fmt.Sprintf(`
     SELECT
-    t.id, t.name, b.id, b.name
+    %s, t.id, t.name, b.id, b.name
     FROM target t
     JOIN body_part b on t.bodypart_id = b.id
-    ;`
+    WHERE %s
+    AND %s
+    ORDER BY %s
+    LIMIT %d
+    ;`, ks.sortKey, filterClause, ks.where, ks.orderBy, ks.limit)
+
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM target t WHERE %s;`, filterClause)
 
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
 	defer cancel()
 
-	rows, err := s.db.QueryContext(ctx, query)
+	var total int
+	if err := s.db.QueryRowContext(ctx, countQuery, filter.BodyPartID).Scan(&total); err != nil {
+		return nil, PageMeta{}, err
+	}
+
+	args := append([]any{filter.BodyPartID}, ks.args...)
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, PageMeta{}, err
 	}
 	defer rows.Close()
 
-	var presentableTargets []PresentableTarget
+	presentableTargets := []PresentableTarget{}
+	var positions []cursor
 	for rows.Next() {
 		var t PresentableTarget
+		var c cursor
 		err := rows.Scan(
+			&c.Key,
 			&t.ID,
 			&t.Name,
 			&t.BodyPartID,
 			&t.BodyPart,
 		)
 		if err != nil {
-			return nil, err
+			return nil, PageMeta{}, err
 		}
+		c.ID = t.ID
 		presentableTargets = append(presentableTargets, t)
+		positions = append(positions, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, PageMeta{}, err
 	}
 
-	return presentableTargets, nil
+	presentableTargets, meta := paginate(page, ks, presentableTargets, positions, total)
+	return presentableTargets, meta, nil
 }
 
 func (s *TargetStore) GetByID(ctx context.Context, id int64) (*PresentableTarget, error) {
@@ -130,7 +155,7 @@ func (s *TargetStore) Update(ctx context.Context, target *PresentableTarget) err
 
 	res, err := s.db.ExecContext(ctx, query, target.Name, target.BodyPartID, target.ID)
 	if err != nil {
-		return err
+		return translateError(err)
 	}
 
 	rowsAffected, err := res.RowsAffected()
@@ -145,34 +170,65 @@ func (s *TargetStore) Update(ctx context.Context, target *PresentableTarget) err
 
 }
 
-func GetTargetsByWorkoutID(db *sql.DB, ctx context.Context, workoutId int64) (*string, []*string, error) {
-	var primaryTarget *string
-	var secondaryTargets []*string
+func GetTargetsByWorkoutID(db queryer, ctx context.Context, workoutId int64) ([]WorkoutTarget, error) {
+	var targets []WorkoutTarget
 
 	query := `
-    SELECT t.name, wt.type
+    SELECT t.id, t.name, wt.type, b.id, b.name
     FROM workout_target wt
     JOIN target t ON t.id = wt.target_id
-    WHERE wt.workout_id = $1`
+    JOIN body_part b ON b.id = t.bodypart_id
+    WHERE wt.workout_id = $1
+    ORDER BY wt.type, t.id`
 
 	rows, err := db.QueryContext(ctx, query, workoutId)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var t Target
-		var targetType *string
-		if err := rows.Scan(&t.Name, &targetType); err != nil {
-			return nil, nil, err
+		var t WorkoutTarget
+		if err := rows.Scan(&t.ID, &t.Name, &t.Type, &t.BodyPartID, &t.BodyPart); err != nil {
+			return nil, err
 		}
-		if targetType != nil && *targetType == "primary" {
-			primaryTarget = &t.Name
-		} else {
-			secondaryTargets = append(secondaryTargets, &t.Name)
+		targets = append(targets, t)
+	}
+
+	return targets, nil
+}
+
+// GetTargetsByWorkoutIDs fetches the target links of several workouts in a
+// single round trip, keyed by workout ID. Each workout's links come in the
+// same order as from GetTargetsByWorkoutID, so secondary_targets is stable.
+func GetTargetsByWorkoutIDs(db queryer, ctx context.Context, workoutIds []int64) (map[int64][]WorkoutTarget, error) {
+	targets := make(map[int64][]WorkoutTarget, len(workoutIds))
+	if len(workoutIds) == 0 {
+		return targets, nil
+	}
+
+	query := `
+    SELECT wt.workout_id, t.id, t.name, wt.type, b.id, b.name
+    FROM workout_target wt
+    JOIN target t ON t.id = wt.target_id
+    JOIN body_part b ON b.id = t.bodypart_id
+    WHERE wt.workout_id = ANY($1)
+    ORDER BY wt.workout_id, wt.type, t.id`
+
+	rows, err := db.QueryContext(ctx, query, pq.Array(workoutIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var workoutId int64
+		var t WorkoutTarget
+		if err := rows.Scan(&workoutId, &t.ID, &t.Name, &t.Type, &t.BodyPartID, &t.BodyPart); err != nil {
+			return nil, err
 		}
+		targets[workoutId] = append(targets[workoutId], t)
 	}
 
-	return primaryTarget, secondaryTargets, nil
+	return targets, rows.Err()
 }
@@ -0,0 +1,267 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrTOTPEnrolled = errors.New("totp is already enrolled")
+	ErrTOTPReplay   = errors.New("totp code was already used")
+	ErrTOTPLocked   = errors.New("totp step-up is locked")
+)
+
+type TOTPStore struct {
+	db *storeDB
+}
+
+type TOTP struct {
+	UserID       int64      `json:"user_id"`
+	Secret       string     `json:"-"`
+	ConfirmedAt  *time.Time `json:"confirmed_at"`
+	LastUsedStep int64      `json:"-"`
+	CreatedAt    string     `json:"created_at"`
+}
+
+func (s *TOTPStore) Get(ctx context.Context, userID int64) (*TOTP, error) {
+	query := `
+    SELECT user_id, secret, confirmed_at, last_used_step, created_at
+    FROM user_totp
+    WHERE user_id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var t TOTP
+	err := s.db.QueryRowContext(ctx, query, userID).Scan(
+		&t.UserID,
+		&t.Secret,
+		&t.ConfirmedAt,
+		&t.LastUsedStep,
+		&t.CreatedAt,
+	)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &t, nil
+}
+
+// Enroll stores a pending secret for the user, replacing any earlier pending
+// one. It returns ErrTOTPEnrolled once an enrollment has been confirmed.
+func (s *TOTPStore) Enroll(ctx context.Context, t *TOTP) error {
+	query := `
+    INSERT INTO user_totp (user_id, secret)
+    VALUES ($1, $2)
+    ON CONFLICT (user_id) DO UPDATE
+    SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+    WHERE user_totp.confirmed_at IS NULL
+    RETURNING created_at
+    ;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	err := s.db.QueryRowContext(ctx, query, t.UserID, t.Secret).Scan(&t.CreatedAt)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return ErrTOTPEnrolled
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Confirm activates a pending enrollment, consuming the time step of the code
+// that proved it, and replaces the user's recovery codes.
+func (s *TOTPStore) Confirm(ctx context.Context, userID, step int64, recoveryCodeHashes [][]byte) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		query := `
+        UPDATE user_totp SET confirmed_at = NOW(), last_used_step = $2
+        WHERE user_id = $1 AND confirmed_at IS NULL
+        ;`
+
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+		defer cancel()
+
+		res, err := tx.ExecContext(ctx, query, userID, step)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1;`, userID); err != nil {
+			return err
+		}
+
+		for _, hash := range recoveryCodeHashes {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO user_recovery_codes (user_id, code_hash) VALUES ($1, $2);`, userID, hash); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// UseStep records that a code from step was accepted. It returns
+// ErrTOTPReplay when that step or a later one was already used, so each code
+// works once.
+func (s *TOTPStore) UseStep(ctx context.Context, userID, step int64) error {
+	query := `
+    UPDATE user_totp SET last_used_step = $2
+    WHERE user_id = $1 AND confirmed_at IS NOT NULL AND last_used_step < $2
+    ;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrTOTPReplay
+	}
+	return nil
+}
+
+// UseRecoveryCode consumes an unused recovery code, returning ErrNotFound
+// when there is none matching.
+func (s *TOTPStore) UseRecoveryCode(ctx context.Context, userID int64, hash []byte) error {
+	query := `
+    UPDATE user_recovery_codes SET used_at = NOW()
+    WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+    ;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, userID, hash)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ClaimAttempt counts a step-up attempt before its code is checked, so
+// concurrent attempts cannot outrun the limit. The attempt that reaches
+// lockAfter locks step-up for lockout. While locked it claims nothing and
+// returns ErrTOTPLocked with the end of the lock.
+func (s *TOTPStore) ClaimAttempt(ctx context.Context, userID int64, lockAfter int, lockout time.Duration) (time.Time, error) {
+	// the count starts again once an earlier lock has expired
+	query := `
+    WITH attempt AS (
+        UPDATE user_totp SET
+            failed_attempts = CASE WHEN locked_until IS NULL THEN failed_attempts + 1 ELSE 1 END,
+            locked_until = CASE
+                WHEN (CASE WHEN locked_until IS NULL THEN failed_attempts + 1 ELSE 1 END) >= $2
+                THEN NOW() + make_interval(secs => $3)
+            END
+        WHERE user_id = $1 AND confirmed_at IS NOT NULL AND (locked_until IS NULL OR locked_until <= NOW())
+        RETURNING user_id
+    )
+    SELECT EXISTS (SELECT 1 FROM attempt), locked_until
+    FROM user_totp
+    WHERE user_id = $1
+    ;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var (
+		claimed     bool
+		lockedUntil *time.Time
+	)
+	err := s.db.QueryRowContext(ctx, query, userID, lockAfter, lockout.Seconds()).Scan(&claimed, &lockedUntil)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return time.Time{}, ErrNotFound
+		default:
+			return time.Time{}, err
+		}
+	}
+
+	if !claimed {
+		if lockedUntil == nil {
+			// only a pending enrollment is neither claimed nor locked
+			return time.Time{}, ErrNotFound
+		}
+		return *lockedUntil, ErrTOTPLocked
+	}
+
+	return time.Time{}, nil
+}
+
+// ResetFailures clears the attempts counted against the user, and a lock the
+// accepted attempt set, once a code was accepted.
+func (s *TOTPStore) ResetFailures(ctx context.Context, userID int64) error {
+	query := `UPDATE user_totp SET failed_attempts = 0, locked_until = NULL WHERE user_id = $1;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := s.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+// Delete removes the user's enrollment and recovery codes.
+func (s *TOTPStore) Delete(ctx context.Context, userID int64) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+		defer cancel()
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1;`, userID); err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, `DELETE FROM user_totp WHERE user_id = $1;`, userID)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
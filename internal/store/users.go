@@ -0,0 +1,352 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrDuplicateEmail    = errors.New("a user with that email already exists")
+	ErrDuplicateUsername = errors.New("a user with that username already exists")
+)
+
+const (
+	RoleMember = "member"
+	RoleCoach  = "coach"
+	RoleAdmin  = "admin"
+)
+
+// Token scopes for the single-use tokens mailed to users.
+const (
+	TokenScopeActivation    = "activation"
+	TokenScopePasswordReset = "password-reset"
+)
+
+type UserStore struct {
+	db *storeDB
+}
+
+type User struct {
+	ID        int64    `json:"id"`
+	Username  string   `json:"username"`
+	Email     string   `json:"email"`
+	Password  password `json:"-"`
+	Role      string   `json:"role"`
+	IsActive  bool     `json:"is_active"`
+	CreatedAt string   `json:"created_at"`
+}
+
+type password struct {
+	text *string
+	hash []byte
+}
+
+func (p *password) Set(text string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(text), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	p.text = &text
+	p.hash = hash
+
+	return nil
+}
+
+func (p *password) Compare(text string) error {
+	return bcrypt.CompareHashAndPassword(p.hash, []byte(text))
+}
+
+// dummyPasswordHash is hashed at the cost of real passwords and belongs to
+// no account.
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("\x00no user\x00"), bcrypt.DefaultCost)
+	return hash
+})
+
+// CompareDummyPassword spends as long as a password check without matching
+// anything, so a login for an unknown email takes as long as a wrong
+// password.
+func CompareDummyPassword(text string) {
+	_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(text))
+}
+
+func (s *UserStore) Create(ctx context.Context, user *User) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		return s.create(ctx, tx, user)
+	})
+}
+
+// CreateAndInvite creates an inactive user together with the activation
+// token mailed to them.
+func (s *UserStore) CreateAndInvite(ctx context.Context, user *User, tokenHash []byte, exp time.Duration) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		if err := s.create(ctx, tx, user); err != nil {
+			return err
+		}
+
+		return s.createToken(ctx, tx, user.ID, TokenScopeActivation, tokenHash, exp)
+	})
+}
+
+func (s *UserStore) create(ctx context.Context, tx *sql.Tx, user *User) error {
+	query := `
+    INSERT INTO users (username, email, password)
+    VALUES ($1, $2, $3)
+    RETURNING id, role, is_active, created_at
+    ;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	err := tx.QueryRowContext(ctx, query, user.Username, user.Email, user.Password.hash).Scan(&user.ID, &user.Role, &user.IsActive, &user.CreatedAt)
+	if err != nil {
+		err = translateError(err)
+
+		// check unique constraints validation
+		var cErr *ConstraintError
+		if errors.As(err, &cErr) && cErr.Err == ErrDuplicate {
+			switch cErr.Constraint {
+			case "users_email_key":
+				return ErrDuplicateEmail
+			case "users_username_key":
+				return ErrDuplicateUsername
+			}
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *UserStore) GetByID(ctx context.Context, id int64) (*User, error) {
+	query := `
+    SELECT id, username, email, password, role, is_active, created_at
+    FROM users
+    WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var user User
+	err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.Password.hash,
+		&user.Role,
+		&user.IsActive,
+		&user.CreatedAt,
+	)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &user, nil
+}
+
+func (s *UserStore) GetByEmail(ctx context.Context, email string) (*User, error) {
+	query := `
+    SELECT id, username, email, password, role, is_active, created_at
+    FROM users
+    WHERE email = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var user User
+	err := s.db.QueryRowContext(ctx, query, email).Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.Password.hash,
+		&user.Role,
+		&user.IsActive,
+		&user.CreatedAt,
+	)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &user, nil
+}
+
+func (s *UserStore) UpdateRole(ctx context.Context, user *User) error {
+	query := `UPDATE users SET role = $1 WHERE id = $2;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, user.Role, user.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *UserStore) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM users WHERE id = $1;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// CreateToken stores the hash of a single-use token for the user. Earlier
+// tokens with the same scope stay valid until they expire or one is used.
+func (s *UserStore) CreateToken(ctx context.Context, userID int64, scope string, tokenHash []byte, exp time.Duration) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		return s.createToken(ctx, tx, userID, scope, tokenHash, exp)
+	})
+}
+
+func (s *UserStore) createToken(ctx context.Context, tx *sql.Tx, userID int64, scope string, tokenHash []byte, exp time.Duration) error {
+	query := `
+    INSERT INTO user_tokens (hash, user_id, scope, expires_at)
+    VALUES ($1, $2, $3, $4)
+    ;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := tx.ExecContext(ctx, query, tokenHash, userID, scope, time.Now().Add(exp))
+	return err
+}
+
+// Activate marks the owner of an activation token active and consumes every
+// activation token they hold.
+func (s *UserStore) Activate(ctx context.Context, tokenHash []byte) (*User, error) {
+	var user *User
+
+	err := withTx(ctx, s.db, func(tx *sql.Tx) error {
+		u, err := s.getUserFromToken(ctx, tx, TokenScopeActivation, tokenHash)
+		if err != nil {
+			return err
+		}
+
+		query := `UPDATE users SET is_active = true WHERE id = $1;`
+
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+		defer cancel()
+
+		if _, err := tx.ExecContext(ctx, query, u.ID); err != nil {
+			return err
+		}
+		u.IsActive = true
+
+		user = u
+		return s.deleteTokens(ctx, tx, u.ID, TokenScopeActivation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ResetPassword replaces the password of the owner of a reset token with the
+// one set on user and consumes every reset token they hold. user is filled
+// in with the owner.
+func (s *UserStore) ResetPassword(ctx context.Context, tokenHash []byte, user *User) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		u, err := s.getUserFromToken(ctx, tx, TokenScopePasswordReset, tokenHash)
+		if err != nil {
+			return err
+		}
+
+		query := `UPDATE users SET password = $1 WHERE id = $2;`
+
+		ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+		defer cancel()
+
+		if _, err := tx.ExecContext(ctx, query, user.Password.hash, u.ID); err != nil {
+			return err
+		}
+
+		u.Password = user.Password
+		*user = *u
+
+		return s.deleteTokens(ctx, tx, u.ID, TokenScopePasswordReset)
+	})
+}
+
+func (s *UserStore) getUserFromToken(ctx context.Context, tx *sql.Tx, scope string, tokenHash []byte) (*User, error) {
+	query := `
+    SELECT u.id, u.username, u.email, u.password, u.role, u.is_active, u.created_at
+    FROM users u
+    JOIN user_tokens t ON t.user_id = u.id
+    WHERE t.hash = $1 AND t.scope = $2 AND t.expires_at > $3
+    FOR UPDATE OF u`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var user User
+	err := tx.QueryRowContext(ctx, query, tokenHash, scope, time.Now()).Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.Password.hash,
+		&user.Role,
+		&user.IsActive,
+		&user.CreatedAt,
+	)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &user, nil
+}
+
+func (s *UserStore) deleteTokens(ctx context.Context, tx *sql.Tx, userID int64, scope string) error {
+	query := `DELETE FROM user_tokens WHERE user_id = $1 AND scope = $2;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := tx.ExecContext(ctx, query, userID, scope)
+	return err
+}
@@ -3,12 +3,14 @@ package store
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 
 	"github.com/lib/pq"
 )
 
 type WorkoutStore struct {
-	db *sql.DB
+	db *storeDB
 }
 
 type WorkoutDifficulty string
@@ -37,18 +39,54 @@ type Workout struct {
 	Difficulty      string   `json:"difficulty"`
 }
 
+// PresentableWorkout is a workout as the API shows it. Besides the names of
+// its body part, equipment and targets it carries their IDs, unlike the
+// original listing, so a client can send an update straight back from it.
 type PresentableWorkout struct {
-	ID               int64     `json:"id"`
-	Name             string    `json:"name"`
-	GifUrl           string    `json:"gif_url"`
-	Instructions     []string  `json:"instructions"`
-	CaloriesBurned   uint8     `json:"calories_burned"`
-	DurationMinutes  uint8     `json:"duration_minutes"`
-	Difficulty       string    `json:"difficulty"`
-	BodyPart         string    `json:"body_part"`
-	Equipment        string    `json:"equipment"`
-	PrimaryTarget    string    `json:"primary_target"`
-	SecondaryTargets []*string `json:"secondary_targets"`
+	Workout
+	BodyPart           string    `json:"body_part"`
+	Equipment          string    `json:"equipment"`
+	PrimaryTargetID    int64     `json:"primary_target_id"`
+	PrimaryTarget      string    `json:"primary_target"`
+	SecondaryTargetIDs []int64   `json:"secondary_target_ids"`
+	SecondaryTargets   []*string `json:"secondary_targets"`
+}
+
+type WorkoutTarget struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	BodyPartID int64  `json:"bodypart_id"`
+	BodyPart   string `json:"body_part"`
+}
+
+func (p *PresentableWorkout) setTargets(targets []WorkoutTarget) {
+	for _, t := range targets {
+		if t.Type == "primary" {
+			p.PrimaryTargetID = t.ID
+			p.PrimaryTarget = t.Name
+		} else {
+			p.SecondaryTargetIDs = append(p.SecondaryTargetIDs, t.ID)
+			p.SecondaryTargets = append(p.SecondaryTargets, &t.Name)
+		}
+	}
+}
+
+// WorkoutSearchResult is a search match. Snippet is HTML: the catalog text
+// is escaped and only the <mark> tags around matches are markup.
+type WorkoutSearchResult struct {
+	PresentableWorkout
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// htmlEscapeSQL wraps a text expression so Postgres escapes it for HTML, the
+// way html.EscapeString does. Words are left intact for ts_headline to match.
+func htmlEscapeSQL(expr string) string {
+	for _, r := range [][2]string{{"&", "&amp;"}, {"<", "&lt;"}, {">", "&gt;"}, {`"`, "&#34;"}, {"'", "&#39;"}} {
+		expr = fmt.Sprintf("replace(%s, '%s', '%s')", expr, strings.ReplaceAll(r[0], "'", "''"), r[1])
+	}
+	return expr
 }
 
 func (s *WorkoutStore) create(ctx context.Context, tx *sql.Tx, workout *Workout) error {
@@ -75,11 +113,7 @@ func (s *WorkoutStore) create(ctx context.Context, tx *sql.Tx, workout *Workout)
 		&workout.DurationMinutes,
 		&workout.Difficulty,
 	).Scan(&workout.ID); err != nil {
-		// check unique constraints validation
-		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23505" {
-			return ErrDuplicate
-		}
-		return err
+		return translateError(err)
 	}
 
 	return nil
@@ -101,6 +135,75 @@ func (s *WorkoutStore) CreateAndLinkTargets(ctx context.Context, workout *Workou
 	})
 }
 
+func (s *WorkoutStore) update(ctx context.Context, tx *sql.Tx, workout *Workout) error {
+	query := `
+    UPDATE workout SET
+    name = $1, bodypart_id = $2, equipment_id = $3, gif_url = $4, instructions = $5,
+    calories_burned = $6, duration_minutes = $7, difficulty = $8
+    WHERE id = $9
+    ;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := tx.ExecContext(
+		ctx,
+		query,
+		workout.Name,
+		workout.BodyPartID,
+		workout.EquipmentID,
+		workout.GifUrl,
+		pq.Array(workout.Instructions),
+		workout.CaloriesBurned,
+		workout.DurationMinutes,
+		workout.Difficulty,
+		workout.ID,
+	)
+	if err != nil {
+		return translateError(err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *WorkoutStore) UpdateAndLinkTargets(ctx context.Context, workout *Workout, primaryTargetId int64, secondaryTargetIds []int64) error {
+	return withTx(ctx, s.db, func(tx *sql.Tx) error {
+		// update workout
+		if err := s.update(ctx, tx, workout); err != nil {
+			return err
+		}
+
+		// replace links between workout and targets
+		if err := s.unlinkTargets(ctx, tx, workout.ID); err != nil {
+			return err
+		}
+
+		if err := s.linkTargets(ctx, tx, workout.ID, primaryTargetId, secondaryTargetIds); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (s *WorkoutStore) unlinkTargets(ctx context.Context, tx *sql.Tx, workoutId int64) error {
+	query := `DELETE FROM workout_target WHERE workout_id = $1;`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := tx.ExecContext(ctx, query, workoutId)
+	return err
+}
+
 func (s *WorkoutStore) linkTargets(ctx context.Context, tx *sql.Tx, workoutId int64, primaryTargetId int64, secondaryTargetIds []int64) error {
 	linkPrimaryTargetquery := `
     INSERT INTO workout_target (workout_id, target_id, type)
@@ -149,32 +252,70 @@ func (s *WorkoutStore) linkTargets(ctx context.Context, tx *sql.Tx, workoutId in
 
 }
 
-func (s *WorkoutStore) GetAll(ctx context.Context) ([]PresentableWorkout, error) {
-	query := `
+// workoutFilterClause narrows workouts using the WorkoutFilter arguments $1
+// to $5, in field order. Zero values disable the corresponding filter.
+const workoutFilterClause = `
+    (w.bodypart_id = $1 OR $1 = 0)
+    AND (w.equipment_id = $2 OR $2 = 0)
+    AND (w.difficulty::text = $3 OR $3 = '')
+    AND ($4 = 0 OR EXISTS (
+        SELECT 1 FROM workout_target wt
+        WHERE wt.workout_id = w.id
+        AND wt.target_id = $4
+        AND (wt.type::text = $5 OR $5 = '')
+    ))`
+
+func (f WorkoutFilter) args() []any {
+	return []any{f.BodyPartID, f.EquipmentID, f.Difficulty, f.TargetID, f.TargetType}
+}
+
+func (s *WorkoutStore) GetAll(ctx context.Context, filter WorkoutFilter, page PaginatedQuery) ([]PresentableWorkout, PageMeta, error) {
+	ks, err := page.keyset(WorkoutSorts, "w.id", 5)
+	if err != nil {
+		return nil, PageMeta{}, err
+	}
+
+	query := fmt.Sprintf(`
     SELECT
-    w.id, w.name, b.name, e.name, w.gif_url, w.difficulty, w.instructions, w.calories_burned, w.duration_minutes
+    %s, w.id, w.name, w.bodypart_id, b.name, w.equipment_id, e.name, w.gif_url, w.difficulty, w.instructions, w.calories_burned, w.duration_minutes
     FROM workout w
     JOIN body_part b ON w.bodypart_id = b.id
     LEFT JOIN equipment e ON w.equipment_id = e.id
-    ;`
+    WHERE %s
+    AND %s
+    ORDER BY %s
+    LIMIT %d
+    ;`, ks.sortKey, workoutFilterClause, ks.where, ks.orderBy, ks.limit)
+
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM workout w WHERE %s;`, workoutFilterClause)
 
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
 	defer cancel()
 
-	rows, err := s.db.QueryContext(ctx, query)
+	var total int
+	if err := s.db.QueryRowContext(ctx, countQuery, filter.args()...).Scan(&total); err != nil {
+		return nil, PageMeta{}, err
+	}
+
+	args := append(filter.args(), ks.args...)
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, PageMeta{}, err
 	}
 	defer rows.Close()
 
 	var presentableWorkouts []PresentableWorkout
+	var positions []cursor
 	for rows.Next() {
 		var p PresentableWorkout
+		var c cursor
 		err := rows.Scan(
-
+			&c.Key,
 			&p.ID,
 			&p.Name,
+			&p.BodyPartID,
 			&p.BodyPart,
+			&p.EquipmentID,
 			&p.Equipment,
 			&p.GifUrl,
 			&p.Difficulty,
@@ -183,19 +324,25 @@ func (s *WorkoutStore) GetAll(ctx context.Context) ([]PresentableWorkout, error)
 			&p.DurationMinutes,
 		)
 		if err != nil {
-			return nil, err
+			return nil, PageMeta{}, err
 		}
 
-		primaryTarget, secondaryTargets, err := GetTargetsByWorkoutID(s.db, ctx, p.ID)
-		p.PrimaryTarget = *primaryTarget
-		p.SecondaryTargets = secondaryTargets
-		if err != nil {
-			return nil, err
-		}
+		c.ID = p.ID
 		presentableWorkouts = append(presentableWorkouts, p)
+		positions = append(positions, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, PageMeta{}, err
 	}
 
-	return presentableWorkouts, nil
+	presentableWorkouts, meta := paginate(page, ks, presentableWorkouts, positions, total)
+
+	if err := s.loadTargets(ctx, presentableWorkouts); err != nil {
+		return nil, PageMeta{}, err
+	}
+
+	return presentableWorkouts, meta, nil
 }
 
 func (s *WorkoutStore) GetByID(ctx context.Context, id int64) (*PresentableWorkout, error) {
@@ -206,7 +353,7 @@ func (s *WorkoutStore) GetByID(ctx context.Context, id int64) (*PresentableWorko
 
 	query := `
     SELECT
-    w.id, w.name, b.name, e.name, w.gif_url, w.difficulty, w.instructions, w.calories_burned, w.duration_minutes
+    w.id, w.name, w.bodypart_id, b.name, w.equipment_id, e.name, w.gif_url, w.difficulty, w.instructions, w.calories_burned, w.duration_minutes
     FROM workout w
     JOIN body_part b ON w.bodypart_id = b.id
     LEFT JOIN equipment e ON w.equipment_id = e.id
@@ -214,7 +361,9 @@ func (s *WorkoutStore) GetByID(ctx context.Context, id int64) (*PresentableWorko
 	err := s.db.QueryRowContext(ctx, query, id).Scan(
 		&presentableWorkout.ID,
 		&presentableWorkout.Name,
+		&presentableWorkout.BodyPartID,
 		&presentableWorkout.BodyPart,
+		&presentableWorkout.EquipmentID,
 		&presentableWorkout.Equipment,
 		&presentableWorkout.GifUrl,
 		&presentableWorkout.Difficulty,
@@ -231,14 +380,114 @@ func (s *WorkoutStore) GetByID(ctx context.Context, id int64) (*PresentableWorko
 		}
 	}
 
-	primaryTarget, secondaryTargets, err := GetTargetsByWorkoutID(s.db, ctx, presentableWorkout.ID)
-	presentableWorkout.PrimaryTarget = *primaryTarget
-	presentableWorkout.SecondaryTargets = secondaryTargets
+	workouts := []PresentableWorkout{presentableWorkout}
+	if err := s.loadTargets(ctx, workouts); err != nil {
+		return nil, err
+	}
+
+	return &workouts[0], nil
+}
+
+// loadTargets attaches the primary and secondary targets to every workout
+// with one batched query instead of a query per workout.
+func (s *WorkoutStore) loadTargets(ctx context.Context, workouts []PresentableWorkout) error {
+	return loadWorkoutTargets(s.db, ctx, workouts)
+}
+
+func loadWorkoutTargets(db queryer, ctx context.Context, workouts []PresentableWorkout) error {
+	ids := make([]int64, len(workouts))
+	for i := range workouts {
+		ids[i] = workouts[i].ID
+	}
+
+	targets, err := GetTargetsByWorkoutIDs(db, ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range workouts {
+		workouts[i].setTargets(targets[workouts[i].ID])
+	}
+
+	return nil
+}
+
+// GetTargets fetches the target links of several workouts, keyed by workout
+// ID.
+func (s *WorkoutStore) GetTargets(ctx context.Context, ids []int64) (map[int64][]WorkoutTarget, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return GetTargetsByWorkoutIDs(s.db, ctx, ids)
+}
+
+// GetByIDs fetches several workouts at once, ordered by id. Ids that don't
+// exist are skipped.
+func (s *WorkoutStore) GetByIDs(ctx context.Context, ids []int64) ([]PresentableWorkout, error) {
+	return getWorkoutsByIDs(s.db, ctx, ids)
+}
+
+func getWorkoutsByIDs(db queryer, ctx context.Context, ids []int64) ([]PresentableWorkout, error) {
+	return queryWorkouts(db, ctx, `w.id = ANY($1)`, pq.Array(ids))
+}
+
+// GetCandidates fetches every workout that matches the filter, ordered by id,
+// for the session generator to choose from.
+func (s *WorkoutStore) GetCandidates(ctx context.Context, filter CandidateFilter) ([]PresentableWorkout, error) {
+	return queryWorkouts(s.db, ctx, candidateFilterClause, filter.args()...)
+}
+
+// queryWorkouts fetches the workouts matching where, ordered by id, with
+// their targets attached.
+func queryWorkouts(db queryer, ctx context.Context, where string, args ...any) ([]PresentableWorkout, error) {
+	query := fmt.Sprintf(`
+    SELECT
+    w.id, w.name, w.bodypart_id, b.name, w.equipment_id, e.name, w.gif_url, w.difficulty, w.instructions, w.calories_burned, w.duration_minutes
+    FROM workout w
+    JOIN body_part b ON w.bodypart_id = b.id
+    LEFT JOIN equipment e ON w.equipment_id = e.id
+    WHERE %s
+    ORDER BY w.id`, where)
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	return &presentableWorkout, nil
+	workouts := []PresentableWorkout{}
+	for rows.Next() {
+		var w PresentableWorkout
+		err := rows.Scan(
+			&w.ID,
+			&w.Name,
+			&w.BodyPartID,
+			&w.BodyPart,
+			&w.EquipmentID,
+			&w.Equipment,
+			&w.GifUrl,
+			&w.Difficulty,
+			pq.Array(&w.Instructions),
+			&w.CaloriesBurned,
+			&w.DurationMinutes,
+		)
+		if err != nil {
+			return nil, err
+		}
+		workouts = append(workouts, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := loadWorkoutTargets(db, ctx, workouts); err != nil {
+		return nil, err
+	}
+
+	return workouts, nil
 }
 
 func (s *WorkoutStore) Delete(ctx context.Context, id int64) error {
@@ -263,25 +512,78 @@ func (s *WorkoutStore) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (s *WorkoutStore) Update(ctx context.Context, workout *PresentableWorkout) error {
-	query := `UPDATE workout SET name = $1, bodypart_id = $2 WHERE id = $3;`
+// Search ranks workouts against a web search style query over the search
+// vector maintained by the workout search triggers. Snippets wrap matched
+// terms in <mark> tags.
+func (s *WorkoutStore) Search(ctx context.Context, sq SearchQuery) ([]WorkoutSearchResult, error) {
+	query := `
+    SELECT
+    w.id, w.name, w.bodypart_id, b.name, w.equipment_id, e.name, w.gif_url, w.difficulty, w.instructions, w.calories_burned, w.duration_minutes,
+    ts_rank(w.search_vector, q) AS rank,
+    ts_headline(
+        'english',
+        ` + htmlEscapeSQL(`concat_ws(' ', w.name, array_to_string(w.instructions, ' '))`) + `,
+        q,
+        'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5'
+    )
+    FROM workout w
+    JOIN body_part b ON w.bodypart_id = b.id
+    LEFT JOIN equipment e ON w.equipment_id = e.id
+    CROSS JOIN websearch_to_tsquery('english', $1) q
+    WHERE w.search_vector @@ q
+    ORDER BY rank DESC, w.id
+    LIMIT $2
+    ;`
 
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
 	defer cancel()
 
-	res, err := s.db.ExecContext(ctx, query, workout.Name, workout.ID)
+	rows, err := s.db.QueryContext(ctx, query, sq.Query, sq.Limit)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer rows.Close()
 
-	rowsAffected, err := res.RowsAffected()
-	if err != nil {
-		return err
+	var results []WorkoutSearchResult
+	for rows.Next() {
+		var r WorkoutSearchResult
+		err := rows.Scan(
+			&r.ID,
+			&r.Name,
+			&r.BodyPartID,
+			&r.BodyPart,
+			&r.EquipmentID,
+			&r.Equipment,
+			&r.GifUrl,
+			&r.Difficulty,
+			pq.Array(&r.Instructions),
+			&r.CaloriesBurned,
+			&r.DurationMinutes,
+			&r.Rank,
+			&r.Snippet,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r)
 	}
 
-	if rowsAffected == 0 {
-		return ErrNotFound
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	workouts := make([]PresentableWorkout, len(results))
+	for i := range results {
+		workouts[i] = results[i].PresentableWorkout
+	}
+
+	if err := s.loadTargets(ctx, workouts); err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].PresentableWorkout = workouts[i]
 	}
-	return nil
 
+	return results, nil
 }
@@ -0,0 +1,74 @@
+package store
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestHTMLEscapeSQL(t *testing.T) {
+	got := htmlEscapeSQL("w.name")
+	want := `replace(replace(replace(replace(replace(w.name, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;')`
+
+	if got != want {
+		t.Errorf("got %s want %s", got, want)
+	}
+}
+
+// TestPresentableWorkoutJSON pins the shape of workouts in API responses, the
+// ID fields are deliberate additions to the names.
+func TestPresentableWorkoutJSON(t *testing.T) {
+	primary, secondary := "pectorals", "triceps"
+
+	w := PresentableWorkout{
+		Workout: Workout{
+			ID:              1,
+			Name:            "bench press",
+			BodyPartID:      2,
+			EquipmentID:     3,
+			GifUrl:          "https://example.com/bench.gif",
+			Instructions:    []string{"lie down", "press"},
+			CaloriesBurned:  50,
+			DurationMinutes: 10,
+			Difficulty:      "intermediate",
+		},
+		BodyPart:           "chest",
+		Equipment:          "barbell",
+		PrimaryTargetID:    4,
+		PrimaryTarget:      primary,
+		SecondaryTargetIDs: []int64{5},
+		SecondaryTargets:   []*string{&secondary},
+	}
+
+	b, err := json.Marshal(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]any{
+		"id":                   1.0,
+		"name":                 "bench press",
+		"bodypart_id":          2.0,
+		"equipment_id":         3.0,
+		"gif_url":              "https://example.com/bench.gif",
+		"instructions":         []any{"lie down", "press"},
+		"calories_burned":      50.0,
+		"duration_minutes":     10.0,
+		"difficulty":           "intermediate",
+		"body_part":            "chest",
+		"equipment":            "barbell",
+		"primary_target_id":    4.0,
+		"primary_target":       "pectorals",
+		"secondary_target_ids": []any{5.0},
+		"secondary_targets":    []any{"triceps"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
@@ -0,0 +1,40 @@
+package strength
+
+// Levels are ordered from weakest to strongest.
+var Levels = []string{"beginner", "novice", "intermediate", "advanced", "elite"}
+
+// Standards holds the 1RM to bodyweight ratio needed for each level, in the
+// order of Levels.
+type Standards [5]float64
+
+type Classification struct {
+	Level string  `json:"level"`
+	Ratio float64 `json:"ratio"`
+	// NextLevel and ToNextLevel are empty at elite, ToNextLevel is in the
+	// same unit as the 1RM and bodyweight passed in
+	NextLevel   string   `json:"next_level,omitempty"`
+	ToNextLevel *float64 `json:"to_next_level,omitempty"`
+}
+
+// Classify places a 1RM at bodyweight on the standards. Lifts short of the
+// beginner ratio are still reported as beginner.
+func Classify(oneRepMax, bodyweight float64, standards Standards) Classification {
+	ratio := oneRepMax / bodyweight
+
+	level := 0
+	for i := 1; i < len(standards); i++ {
+		if ratio >= standards[i] {
+			level = i
+		}
+	}
+
+	c := Classification{Level: Levels[level], Ratio: ratio}
+
+	if next := level + 1; next < len(Levels) {
+		missing := standards[next]*bodyweight - oneRepMax
+		c.NextLevel = Levels[next]
+		c.ToNextLevel = &missing
+	}
+
+	return c
+}
@@ -0,0 +1,125 @@
+// Package strength estimates one-rep maxes from submaximal sets and derives
+// training loads and strength levels from them.
+package strength
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	Epley    = "epley"
+	Brzycki  = "brzycki"
+	Lombardi = "lombardi"
+	RPE      = "rpe"
+
+	// MaxReps is the highest rep count the formulas are trusted for, they
+	// drift apart quickly past it.
+	MaxReps = 12
+)
+
+var (
+	ErrInvalidSet = errors.New("set must have a positive load and between 1 and 12 reps")
+	// Formulas lists the estimators in the order they are reported.
+	Formulas = []string{Epley, Brzycki, Lombardi, RPE}
+)
+
+// rpeTable is the percentage of 1RM that can be lifted for a given number of
+// reps at RPE 10, indexed by reps - 1. Reps in reserve at lower RPEs are
+// added to the reps performed before looking up the percentage.
+var rpeTable = []float64{
+	1.000, 0.955, 0.922, 0.892, 0.863, 0.837,
+	0.811, 0.786, 0.762, 0.739, 0.707, 0.680,
+}
+
+type Set struct {
+	Load float64
+	Reps int
+	// RPE is the rating of perceived exertion from 1 to 10, zero when the
+	// set was not rated
+	RPE float64
+}
+
+// Estimate returns the estimated 1RM of a set by each formula. A single rep
+// is its own max for every formula, and the RPE formula is only present when
+// the set was rated and its reps in reserve stay within the table.
+func Estimate(set Set) (map[string]float64, error) {
+	if set.Load <= 0 || set.Reps < 1 || set.Reps > MaxReps {
+		return nil, ErrInvalidSet
+	}
+
+	load, reps := set.Load, float64(set.Reps)
+
+	estimates := map[string]float64{
+		Epley:    load * (1 + reps/30),
+		Brzycki:  load * 36 / (37 - reps),
+		Lombardi: load * math.Pow(reps, 0.10),
+	}
+
+	if set.Reps == 1 {
+		estimates[Epley], estimates[Brzycki], estimates[Lombardi] = load, load, load
+	}
+
+	if pct, ok := rpePercentage(reps, set.RPE); ok {
+		estimates[RPE] = load / pct
+	}
+
+	return estimates, nil
+}
+
+// rpePercentage looks up the percentage of 1RM a set of reps at rpe
+// represents, interpolating between whole reps for half-point ratings.
+func rpePercentage(reps, rpe float64) (float64, bool) {
+	if rpe < 1 || rpe > 10 {
+		return 0, false
+	}
+
+	effective := reps + (10 - rpe)
+	if effective > float64(len(rpeTable)) {
+		return 0, false
+	}
+
+	lo := int(math.Floor(effective))
+	frac := effective - float64(lo)
+	if frac == 0 {
+		return rpeTable[lo-1], true
+	}
+
+	return rpeTable[lo-1] + (rpeTable[lo]-rpeTable[lo-1])*frac, true
+}
+
+// Mean averages the estimates of a set.
+func Mean(estimates map[string]float64) float64 {
+	var sum float64
+	for _, e := range estimates {
+		sum += e
+	}
+	return sum / float64(len(estimates))
+}
+
+// RoundTo rounds value to the nearest multiple of increment, such as the
+// smallest plate pair available.
+func RoundTo(value, increment float64) float64 {
+	if increment <= 0 {
+		return value
+	}
+	return math.Round(value/increment) * increment
+}
+
+type Load struct {
+	Percent int     `json:"percent"`
+	Load    float64 `json:"load"`
+}
+
+// LoadTable lists 50% to 100% of oneRepMax in steps of 5%, rounded to
+// increment.
+func LoadTable(oneRepMax, increment float64) []Load {
+	table := make([]Load, 0, 11)
+	for pct := 50; pct <= 100; pct += 5 {
+		table = append(table, Load{
+			Percent: pct,
+			Load:    RoundTo(oneRepMax*float64(pct)/100, increment),
+		})
+	}
+	return table
+}
@@ -0,0 +1,117 @@
+package strength
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		name    string
+		set     Set
+		want    map[string]float64
+		wantErr bool
+	}{
+		{
+			"five reps unrated",
+			Set{Load: 100, Reps: 5},
+			map[string]float64{Epley: 116.67, Brzycki: 112.5, Lombardi: 117.46},
+			false,
+		},
+		{
+			"five reps at rpe 8",
+			Set{Load: 100, Reps: 5, RPE: 8},
+			map[string]float64{Epley: 116.67, Brzycki: 112.5, Lombardi: 117.46, RPE: 123.30},
+			false,
+		},
+		{
+			"half point rpe interpolates",
+			Set{Load: 100, Reps: 1, RPE: 9.5},
+			map[string]float64{Epley: 100, Brzycki: 100, Lombardi: 100, RPE: 102.30},
+			false,
+		},
+		{
+			"reps in reserve past the table",
+			Set{Load: 60, Reps: 10, RPE: 6},
+			map[string]float64{Epley: 80, Brzycki: 80, Lombardi: 75.54},
+			false,
+		},
+		{"too many reps", Set{Load: 60, Reps: 13}, nil, true},
+		{"no load", Set{Reps: 5}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Estimate(tt.set)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v want %v", got, tt.want)
+			}
+			for formula, want := range tt.want {
+				if math.Abs(got[formula]-want) > 0.01 {
+					t.Errorf("%s: got %.2f want %.2f", formula, got[formula], want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadTable(t *testing.T) {
+	table := LoadTable(142, 2.5)
+
+	if len(table) != 11 {
+		t.Fatalf("got %d rows want 11", len(table))
+	}
+
+	want := map[int]float64{50: 70, 75: 107.5, 90: 127.5, 100: 142.5}
+	for _, row := range table {
+		if w, ok := want[row.Percent]; ok && row.Load != w {
+			t.Errorf("%d%%: got %v want %v", row.Percent, row.Load, w)
+		}
+	}
+}
+
+func TestClassify(t *testing.T) {
+	standards := Standards{0.5, 0.75, 1.25, 1.75, 2.25}
+
+	tests := []struct {
+		name        string
+		oneRepMax   float64
+		wantLevel   string
+		wantNext    string
+		wantMissing float64
+	}{
+		{"below beginner", 20, "beginner", "novice", 40},
+		{"intermediate", 110, "intermediate", "advanced", 30},
+		{"exactly advanced", 140, "advanced", "elite", 40},
+		{"elite", 200, "elite", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Classify(tt.oneRepMax, 80, standards)
+
+			if got.Level != tt.wantLevel || got.NextLevel != tt.wantNext {
+				t.Errorf("got %s -> %s want %s -> %s", got.Level, got.NextLevel, tt.wantLevel, tt.wantNext)
+			}
+
+			if tt.wantNext == "" {
+				if got.ToNextLevel != nil {
+					t.Errorf("got %v to next level at elite", *got.ToNextLevel)
+				}
+				return
+			}
+
+			if got.ToNextLevel == nil || math.Abs(*got.ToNextLevel-tt.wantMissing) > 0.01 {
+				t.Errorf("got %v to next level want %v", got.ToNextLevel, tt.wantMissing)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Package units converts body measurements between the metric values the
+// store keeps and the units users prefer.
+package units
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	Kilogram   = "kg"
+	Pound      = "lb"
+	Centimeter = "cm"
+	Inch       = "in"
+
+	poundsPerKilogram  = 2.2046226218
+	centimetersPerInch = 2.54
+)
+
+// ToKilograms converts a weight in unit to kilograms.
+func ToKilograms(value float64, unit string) (float64, error) {
+	switch unit {
+	case Kilogram:
+		return value, nil
+	case Pound:
+		return value / poundsPerKilogram, nil
+	default:
+		return 0, fmt.Errorf("unknown weight unit %q", unit)
+	}
+}
+
+// FromKilograms converts a weight in kilograms to unit.
+func FromKilograms(kg float64, unit string) (float64, error) {
+	switch unit {
+	case Kilogram:
+		return kg, nil
+	case Pound:
+		return kg * poundsPerKilogram, nil
+	default:
+		return 0, fmt.Errorf("unknown weight unit %q", unit)
+	}
+}
+
+// ToCentimeters converts a length in unit to centimeters.
+func ToCentimeters(value float64, unit string) (float64, error) {
+	switch unit {
+	case Centimeter:
+		return value, nil
+	case Inch:
+		return value * centimetersPerInch, nil
+	default:
+		return 0, fmt.Errorf("unknown length unit %q", unit)
+	}
+}
+
+// FromCentimeters converts a length in centimeters to unit.
+func FromCentimeters(cm float64, unit string) (float64, error) {
+	switch unit {
+	case Centimeter:
+		return cm, nil
+	case Inch:
+		return cm / centimetersPerInch, nil
+	default:
+		return 0, fmt.Errorf("unknown length unit %q", unit)
+	}
+}
+
+// Round rounds v to the given number of decimal places.
+func Round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
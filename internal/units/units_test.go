@@ -0,0 +1,36 @@
+package units
+
+import "testing"
+
+func TestConversions(t *testing.T) {
+	tests := []struct {
+		name    string
+		convert func(float64, string) (float64, error)
+		value   float64
+		unit    string
+		want    float64
+	}{
+		{"lb to kg", ToKilograms, 220.5, Pound, 100},
+		{"kg to kg", ToKilograms, 80, Kilogram, 80},
+		{"kg to lb", FromKilograms, 100, Pound, 220.5},
+		{"in to cm", ToCentimeters, 70, Inch, 177.8},
+		{"cm to in", FromCentimeters, 177.8, Inch, 70},
+		{"cm to cm", FromCentimeters, 180, Centimeter, 180},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.convert(tt.value, tt.unit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if Round(got, 1) != tt.want {
+				t.Errorf("got %v want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ToKilograms(1, "stone"); err == nil {
+		t.Error("expected an error for an unknown unit")
+	}
+}